@@ -0,0 +1,441 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/auth"
+	"one/internal/config"
+)
+
+// services lists every service one can hold credentials for
+var services = []string{"github", "gitlab", "bitbucket", "jira", "linear"}
+
+var loginCmd = &cobra.Command{
+	Use:       "login [service]",
+	Short:     "Authenticate with a git provider or ticket system",
+	Long:      `Stores a token for the current project in the system keyring. Defaults to the project's git provider.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: services,
+	RunE:      runLogin,
+}
+
+var logoutCmd = &cobra.Command{
+	Use:       "logout [service]",
+	Short:     "Remove stored credentials",
+	Long:      `Removes the current project's tokens from the system keyring. Without a service, all of them are removed.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: services,
+	RunE:      runLogout,
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show authentication status for all projects",
+	Long:  `Shows where each project's tokens come from and checks them against the service API.`,
+	Args:  cobra.NoArgs,
+	RunE:  runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+	rootCmd.AddCommand(statusCmd)
+
+	loginCmd.Flags().Bool("with-token", false, "Paste a token instead of using the GitHub device flow or Jira OAuth")
+}
+
+// resolvedToken is a credential and where it was found
+type resolvedToken struct {
+	Value  string
+	Source string // "keyring", "env" or empty when not found
+	EnvVar string
+}
+
+// resolveToken looks a service token up in the keyring, then in envVar
+func resolveToken(cfg *config.ProjectConfig, service, envVar string) resolvedToken {
+	if token, err := auth.GetToken(service, cfg.Project.Name); err == nil {
+		return resolvedToken{Value: token.AccessToken, Source: "keyring", EnvVar: envVar}
+	}
+
+	if envVar != "" {
+		if token := os.Getenv(envVar); token != "" {
+			// A plain Jira Cloud API token is paired with the configured email
+			if service == "jira" && cfg.Ticket != nil && cfg.Ticket.Jira != nil && cfg.Ticket.Jira.Email != "" &&
+				jiraAuthMode(cfg) == api.JiraAuthCloud && !strings.Contains(token, ":") {
+				token = cfg.Ticket.Jira.Email + ":" + token
+			}
+			return resolvedToken{Value: token, Source: "env", EnvVar: envVar}
+		}
+	}
+
+	return resolvedToken{EnvVar: envVar}
+}
+
+// gitTokenEnv returns the environment variable holding the git provider token
+func gitTokenEnv(cfg *config.ProjectConfig) string {
+	switch cfg.Git.Provider {
+	case "github":
+		if cfg.Git.GitHub != nil {
+			return cfg.Git.GitHub.TokenEnv
+		}
+	case "gitlab":
+		if cfg.Git.GitLab != nil {
+			return cfg.Git.GitLab.TokenEnv
+		}
+	case "bitbucket":
+		if cfg.Git.Bitbucket != nil {
+			return cfg.Git.Bitbucket.TokenEnv
+		}
+	}
+	return ""
+}
+
+// ticketTokenEnv returns the environment variable holding the ticket system
+// token. GitHub Issues falls back to the git provider's variable.
+func ticketTokenEnv(cfg *config.ProjectConfig) string {
+	switch cfg.Ticket.System {
+	case "jira":
+		if cfg.Ticket.Jira != nil {
+			return cfg.Ticket.Jira.TokenEnv
+		}
+	case "linear":
+		if cfg.Ticket.Linear != nil {
+			return cfg.Ticket.Linear.TokenEnv
+		}
+	case "github":
+		if cfg.Ticket.GitHub != nil && cfg.Ticket.GitHub.TokenEnv != "" {
+			return cfg.Ticket.GitHub.TokenEnv
+		}
+		if cfg.Git.GitHub != nil {
+			return cfg.Git.GitHub.TokenEnv
+		}
+	}
+	return ""
+}
+
+// projectService is a service a project talks to
+type projectService struct {
+	Name   string
+	EnvVar string
+}
+
+// projectServices returns the services a project uses, git provider first
+func projectServices(cfg *config.ProjectConfig) []projectService {
+	list := []projectService{{Name: cfg.Git.Provider, EnvVar: gitTokenEnv(cfg)}}
+	if cfg.Ticket != nil && cfg.Ticket.System != cfg.Git.Provider {
+		switch cfg.Ticket.System {
+		case "jira", "linear", "github":
+			list = append(list, projectService{Name: cfg.Ticket.System, EnvVar: ticketTokenEnv(cfg)})
+		}
+	}
+	return list
+}
+
+// findProjectService returns the named service of a project
+func findProjectService(cfg *config.ProjectConfig, name string) (projectService, error) {
+	for _, service := range projectServices(cfg) {
+		if service.Name == name {
+			return service, nil
+		}
+	}
+	return projectService{}, fmt.Errorf("project %s does not use %s", cfg.Project.Name, name)
+}
+
+// currentUser validates a token by asking the service who it belongs to
+func currentUser(cfg *config.ProjectConfig, service, token string) (*api.User, error) {
+	switch service {
+	case "github":
+		return newGitHubClient(cfg, token).CurrentUser()
+	case "gitlab":
+		return newGitLabClient(cfg, token).CurrentUser()
+	case "bitbucket":
+		return newBitbucketClient(cfg, token).CurrentUser()
+	case "jira":
+		return newJiraClient(cfg, token).CurrentUser()
+	case "linear":
+		return api.NewLinearClient(token).CurrentUser()
+	default:
+		return nil, fmt.Errorf("unsupported service: %s", service)
+	}
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	withToken, _ := cmd.Flags().GetBool("with-token")
+
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	name := cfg.Git.Provider
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	if _, err := findProjectService(cfg, name); err != nil {
+		return err
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+
+	// The device flow only works against github.com
+	if name == "github" && !withToken && (cfg.Git.GitHub == nil || cfg.Git.GitHub.BaseURL == "") {
+		token, err := auth.GitHubDeviceFlow(cfg.Project.Name)
+		if err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+
+		user, err := currentUser(cfg, name, token.AccessToken)
+		if err != nil {
+			return explainAPIError(name, err)
+		}
+
+		fmt.Println()
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Logged in to github as %s", user.Login)))
+		return nil
+	}
+
+	if name == "jira" && !withToken && jiraAuthMode(cfg) == api.JiraAuthOAuth {
+		app, err := jiraOAuthApp(cfg)
+		if err != nil {
+			return err
+		}
+
+		token, err := auth.AtlassianOAuthFlow(app, cfg.Ticket.BaseURL, cfg.Project.Name)
+		if err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+
+		user, err := currentUser(cfg, name, token.AccessToken)
+		if err != nil {
+			return explainAPIError(name, err)
+		}
+
+		fmt.Println()
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Logged in to jira as %s", user.Login)))
+		return nil
+	}
+
+	token, err := promptToken(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	// Check the token before storing it
+	user, err := currentUser(cfg, name, token)
+	if err != nil {
+		return explainAPIError(name, err)
+	}
+
+	if err := auth.StoreToken(name, cfg.Project.Name, &auth.Token{AccessToken: token, TokenType: "bearer"}); err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Logged in to %s as %s", name, user.Login)))
+	fmt.Printf("  Token stored in the system keyring for %s\n", cfg.Project.Name)
+	return nil
+}
+
+// promptToken asks for a service token, combining it with the username the
+// way the API client expects
+func promptToken(cfg *config.ProjectConfig, service string) (string, error) {
+	var username, token string
+
+	tokenInput := huh.NewInput().
+		Title("Token").
+		EchoMode(huh.EchoModePassword).
+		Value(&token).
+		Validate(func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("token is required")
+			}
+			return nil
+		})
+
+	var fields []huh.Field
+	switch service {
+	case "jira":
+		if jiraAuthMode(cfg) == api.JiraAuthPAT {
+			tokenInput.Title("Personal Access Token").
+				Description("Create one under Profile > Personal Access Tokens in Jira")
+			break
+		}
+		if cfg.Ticket.Jira != nil {
+			username = cfg.Ticket.Jira.Email
+		}
+		fields = append(fields, huh.NewInput().
+			Title("Email").
+			Description("The Atlassian account the API token belongs to").
+			Value(&username))
+		tokenInput.Title("API Token").
+			Description("Create one at https://id.atlassian.com/manage-profile/security/api-tokens")
+	case "bitbucket":
+		fields = append(fields, huh.NewInput().
+			Title("Username").
+			Description("Leave empty when using an access token").
+			Value(&username))
+		tokenInput.Title("App Password or Access Token")
+	default:
+		tokenInput.Description(fmt.Sprintf("A personal access token for %s", service))
+	}
+	fields = append(fields, tokenInput)
+
+	if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return "", err
+	}
+
+	token = strings.TrimSpace(token)
+	username = strings.TrimSpace(username)
+
+	switch {
+	case service == "jira" && username != "":
+		// The Jira client encodes email:token as Basic credentials
+		return username + ":" + token, nil
+	case service == "bitbucket" && username != "":
+		return username + ":" + token, nil
+	}
+
+	return token, nil
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if len(args) > 0 {
+		if _, err := findProjectService(cfg, args[0]); err != nil {
+			return err
+		}
+		names = append(names, args[0])
+	} else {
+		for _, service := range projectServices(cfg) {
+			names = append(names, service.Name)
+		}
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	for _, name := range names {
+		if !auth.HasToken(name, cfg.Project.Name) {
+			fmt.Printf("  No stored %s token for %s\n", name, cfg.Project.Name)
+			continue
+		}
+		if err := auth.DeleteToken(name, cfg.Project.Name); err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Logged out of %s", name)))
+	}
+
+	return nil
+}
+
+// credentialStatus is the result of checking one project service
+type credentialStatus struct {
+	Service projectService
+	Token   resolvedToken
+	User    *api.User
+	Err     error
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	projects, err := config.ListProjects()
+	if err != nil {
+		return err
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No projects configured. Run 'one init' to create one.")
+		return nil
+	}
+
+	// Validate all tokens concurrently
+	results := make([][]credentialStatus, len(projects))
+	var wg sync.WaitGroup
+	for i, project := range projects {
+		for _, service := range projectServices(project) {
+			status := credentialStatus{
+				Service: service,
+				Token:   resolveToken(project, service.Name, service.EnvVar),
+			}
+			results[i] = append(results[i], status)
+		}
+
+		for j := range results[i] {
+			status := &results[i][j]
+			if status.Token.Source == "" {
+				continue
+			}
+			wg.Add(1)
+			go func(cfg *config.ProjectConfig) {
+				defer wg.Done()
+				token := status.Token.Value
+				// Refresh an expired Atlassian OAuth token like the Jira commands do
+				if status.Service.Name == "jira" && status.Token.Source == "keyring" && jiraAuthMode(cfg) == api.JiraAuthOAuth {
+					if token, status.Err = jiraOAuthToken(cfg); status.Err != nil {
+						return
+					}
+				}
+				status.User, status.Err = currentUser(cfg, status.Service.Name, token)
+			}(project)
+		}
+	}
+	wg.Wait()
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	for i, project := range projects {
+		fmt.Println(titleStyle.Render(project.Project.Name))
+
+		for _, status := range results[i] {
+			name := fmt.Sprintf("%-10s", status.Service.Name)
+
+			switch {
+			case status.Token.Source == "":
+				hint := fmt.Sprintf("run 'one login %s'", status.Service.Name)
+				if status.Service.EnvVar != "" {
+					hint += fmt.Sprintf(" or set %s", status.Service.EnvVar)
+				}
+				fmt.Printf("  %s %s %s\n", errorStyle.Render("✗"), name, dimStyle.Render("not authenticated, "+hint))
+
+			case status.Err != nil:
+				fmt.Printf("  %s %s %s  %s\n", errorStyle.Render("✗"), name, tokenSource(status.Token),
+					errorStyle.Render(status.Err.Error()))
+
+			default:
+				user := status.User.Login
+				if status.User.Name != "" && status.User.Name != user {
+					user += " (" + status.User.Name + ")"
+				}
+				line := fmt.Sprintf("  %s %s %s  %s", successStyle.Render("✓"), name, tokenSource(status.Token), user)
+				if len(status.User.Scopes) > 0 {
+					line += dimStyle.Render("  scopes: " + strings.Join(status.User.Scopes, ", "))
+				}
+				fmt.Println(line)
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// tokenSource describes where a token was found
+func tokenSource(token resolvedToken) string {
+	if token.Source == "env" {
+		return fmt.Sprintf("%-8s", "$"+token.EnvVar)
+	}
+	return fmt.Sprintf("%-8s", token.Source)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"one/internal/api"
+	"one/internal/auth"
+	"one/internal/config"
+)
+
+// newGitHubClient creates a GitHub client for the project's GitHub instance
+func newGitHubClient(cfg *config.ProjectConfig, token string) *api.GitHubClient {
+	var opts []api.Option
+	if gh := cfg.Git.GitHub; gh != nil {
+		opts = append(opts, api.WithBaseURL(gh.BaseURL), api.WithCACert(gh.CACert))
+	}
+	return api.NewGitHubClient(token, opts...)
+}
+
+// newGitLabClient creates a GitLab client for the project's GitLab instance
+func newGitLabClient(cfg *config.ProjectConfig, token string) *api.GitLabClient {
+	var opts []api.Option
+	if gl := cfg.Git.GitLab; gl != nil {
+		opts = append(opts, api.WithBaseURL(gl.BaseURL), api.WithCACert(gl.CACert))
+	}
+	return api.NewGitLabClient(token, opts...)
+}
+
+// newBitbucketClient creates a Bitbucket Cloud client
+func newBitbucketClient(cfg *config.ProjectConfig, token string) *api.BitbucketClient {
+	return api.NewBitbucketClient(token)
+}
+
+// newJiraClient creates a Jira client with the project's auth mode and API
+// version. OAuth tokens are scoped to the site's api.atlassian.com API root,
+// which is stored with the token.
+func newJiraClient(cfg *config.ProjectConfig, token string) *api.JiraClient {
+	baseURL := cfg.Ticket.BaseURL
+	mode := jiraAuthMode(cfg)
+	if mode == api.JiraAuthOAuth {
+		if stored, err := auth.GetToken("jira", cfg.Project.Name); err == nil && stored.Resource != "" {
+			baseURL = stored.Resource
+		}
+	}
+
+	var version int
+	if cfg.Ticket.Jira != nil {
+		version = cfg.Ticket.Jira.APIVersion
+	}
+
+	return api.NewJiraClient(baseURL, token).WithAuth(mode).WithAPIVersion(version)
+}
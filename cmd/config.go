@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"one/internal/config"
+	"one/internal/hooks"
 )
 
 var configCmd = &cobra.Command{
@@ -28,10 +34,29 @@ var configShowCmd = &cobra.Command{
 	RunE:  runConfigShow,
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check all project configurations for errors",
+	// Diagnostics are the output; a failed validation is not a usage error
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runConfigValidate,
+}
+
+var configWhichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Explain which project configuration applies here",
+	RunE:  runConfigWhich,
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configWhichCmd)
+
+	configShowCmd.Flags().Bool("resolved", false, "Annotate each value with the layer it came from")
 }
 
 // renderMarkdown renders markdown with Glamour
@@ -96,7 +121,8 @@ one init
 	rendered, err := renderMarkdown(markdown.String())
 	if err != nil {
 		// Fallback to plain text
-		fmt.Println("Configured Projects:\n")
+		fmt.Println("Configured Projects:")
+		fmt.Println()
 		for _, project := range projects {
 			fmt.Printf("  ● %s\n", project.Project.Name)
 			fmt.Printf("    Provider: %s\n", project.Git.Provider)
@@ -119,7 +145,14 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(cfg)
+	resolved, _ := cmd.Flags().GetBool("resolved")
+
+	var data []byte
+	if resolved {
+		data, err = cfg.AnnotatedYAML()
+	} else {
+		data, err = yaml.Marshal(cfg)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to serialize config: %w", err)
 	}
@@ -127,6 +160,10 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	// Create markdown formatted output with syntax highlighting
 	markdown := fmt.Sprintf("# Current Project Configuration\n\n**Project**: %s\n\n## Configuration\n\n```yaml\n%s```\n",
 		cfg.Project.Name, string(data))
+	if resolved {
+		markdown += "\nValues are resolved from built-in defaults, `~/.config/one/config.yml`, the project file, " +
+			"the repository's `.one.yml` and `ONE_*` environment variables, in increasing order of precedence.\n"
+	}
 
 	// Try to render with Glow
 	rendered, err := renderMarkdown(markdown)
@@ -140,3 +177,124 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Println(rendered)
 	return nil
 }
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	files, diags, err := config.ValidateProjects()
+	if err != nil {
+		return err
+	}
+
+	// Hooks are validated by the hooks package, which depends on config
+	for _, file := range files {
+		if file.Config.Hooks == nil {
+			continue
+		}
+		stages := map[string][]config.Hook{
+			"before_pr":   file.Config.Hooks.BeforePR,
+			"after_pr":    file.Config.Hooks.AfterPR,
+			"after_merge": file.Config.Hooks.AfterMerge,
+		}
+		for _, stage := range []string{"before_pr", "after_pr", "after_merge"} {
+			for i, hook := range stages[stage] {
+				if err := hooks.ValidateHooks([]config.Hook{hook}); err != nil {
+					diags = file.Error(diags, fmt.Sprintf("%s hook %d: %v", stage, i+1, err), "hooks", stage, strconv.Itoa(i))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+
+	var errorCount, warningCount int
+	for _, diag := range diags {
+		if diag.Severity == config.SeverityError {
+			errorCount++
+			fmt.Println(errorStyle.Render("✗ ") + diag.String())
+		} else {
+			warningCount++
+			fmt.Println(warningStyle.Render("⚠ ") + diag.String())
+		}
+	}
+
+	if len(diags) > 0 {
+		fmt.Println()
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d error(s), %d warning(s) found", errorCount, warningCount)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ %d project configuration(s) valid", len(files))))
+	if warningCount > 0 {
+		fmt.Printf("  %d warning(s)\n", warningCount)
+	}
+	return nil
+}
+
+func runConfigWhich(cmd *cobra.Command, args []string) error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	lookup, err := config.LookupProject(currentDir)
+	if err != nil {
+		return err
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	fmt.Printf("Directory: %s\n", lookup.Dir)
+	for _, remote := range lookup.Remotes {
+		fmt.Printf("Remote:    %s\n", remote)
+	}
+	fmt.Println()
+
+	best := lookup.Best()
+	if best == nil {
+		fmt.Println(errorStyle.Render("✗ No project matches this directory"))
+	} else {
+		fmt.Println(successStyle.Render("✓ ") + titleStyle.Render(best.Name))
+		fmt.Printf("  File:   %s\n", best.File)
+		fmt.Printf("  Reason: %s\n", describeMatch(*best))
+	}
+
+	if len(lookup.Matches) > 1 {
+		fmt.Println()
+		fmt.Println("Less specific matches:")
+		for _, match := range lookup.Matches[1:] {
+			fmt.Println(dimStyle.Render(fmt.Sprintf("  %s (%s): %s", match.Name, filepath.Base(match.File), describeMatch(match))))
+		}
+	}
+
+	if len(lookup.Invalid) > 0 {
+		fmt.Println()
+		fmt.Println(errorStyle.Render("Skipped invalid files: " + strings.Join(lookup.Invalid, ", ")))
+		fmt.Println("  Run 'one config validate' for details")
+	}
+
+	return nil
+}
+
+// describeMatch explains a project match in words
+func describeMatch(match config.ProjectMatch) string {
+	if match.Kind == config.MatchRemote {
+		return fmt.Sprintf("remote %s matches %s", match.Target, match.Pattern)
+	}
+	if match.Glob {
+		return fmt.Sprintf("directory matches path pattern %s", match.Pattern)
+	}
+	return fmt.Sprintf("directory is inside path %s", match.Pattern)
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"one/internal/api"
+)
+
+// explainAPIError adds an actionable hint to provider API errors
+func explainAPIError(service string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case api.IsAuth(err):
+		return fmt.Errorf("%w\n  Your %s token was rejected or lacks the required scopes", err, service)
+	case api.IsNotFound(err):
+		return fmt.Errorf("%w\n  Check the %s settings in your project configuration, or that your token can see them", err, service)
+	case api.IsRateLimited(err):
+		return fmt.Errorf("%w\n  %s rate limit reached, try again later", err, service)
+	default:
+		return err
+	}
+}
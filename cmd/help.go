@@ -103,60 +103,214 @@ one init --name "My Project"
 
 ---
 
-### **one start** <TICKET-ID> [-d DESCRIPTION]
+### **one start** [TICKET-ID] [-d DESCRIPTION]
 Start working on a new task.
 
 **Examples:**
 ` + "```bash" + `
 one start PROJ-1234
 one start PROJ-1234 --description "Add user authentication"
+one start            # pick one of your assigned tickets
 ` + "```" + `
 
+If the working directory has uncommitted changes, one stashes them and either
+brings them onto the new branch or leaves them parked for later.
+
+### **one stash**
+List the stashes one created, with the ticket and branch they belong to.
+
 ---
 
-### **one pr** [-t TITLE] [-d DESCRIPTION] [--no-browser]
+### **one pr** [-t TITLE] [-d DESCRIPTION] [--draft] [--refresh] [--yes] [--no-browser]
 Create and open a pull request.
 
 **Examples:**
 ` + "```bash" + `
 one pr
 one pr --title "feat: Add OAuth support"
+one pr --draft
 one pr --no-browser
+one pr --yes
+one pr --reviewer alice --reviewer acme/backend --label bug
+` + "```" + `
+
+Reviewers, assignees, labels and a milestone come from ` + "`--reviewer`" + `,
+` + "`--assignee`" + `, ` + "`--label`" + ` and ` + "`--milestone`" + ` combined with the project defaults. On GitHub, ` + "`org/team`" + ` requests a team review; on
+GitLab usernames are looked up for you, and names that cannot be found or
+groups are skipped with a warning. Reviewers from the repository's
+CODEOWNERS are suggested in the review step, or always requested with
+` + "`codeowners: true`" + `:
+
+` + "```yaml" + `
+git:
+  pr:
+    reviewers: [alice]
+    assignees: [bob]
+    labels: [needs-review]
+    milestone: "v2.0"
+    codeowners: true
+    merge_method: squash   # default for one pr merge
+` + "```" + `
+
+Before anything is pushed, one shows the rendered title and body for review.
+From there you can edit them in a form or in ` + "`$VISUAL`/`$EDITOR`" + ` (the first
+line is the title), pick the base branch, reviewers and labels, or cancel.
+` + "`--yes`" + ` skips the review, as does running without a terminal.
+
+Set ` + "`git.default_draft: true`" + ` to always open drafts.
+
+Re-running ` + "`one pr`" + ` on a branch that already has an open PR pushes and
+opens the existing PR. Add ` + "`--refresh`" + ` to re-render its title and body
+from your templates.
+
+### **one pr ready**
+Mark the current branch's draft PR/MR as ready for review.
+
+### **one pr merge** [--merge|--squash|--rebase] [--auto] [--keep-branch]
+Merge the current branch's PR/MR, check out and pull ` + "`base_branch`" + `, and
+delete the feature branch locally and on the remote. Without a flag the
+method comes from ` + "`git.pr.merge_method`" + ` or is asked for. ` + "`--auto`" + ` lets the
+provider merge once checks pass (GitHub, GitLab). ` + "`after_merge`" + ` hooks run
+at the end.
+
+On GitLab, ` + "`--rebase`" + ` rebases the MR's branch onto its target first, waits for
+the rebase, then merges with the project's merge method (merge commit or
+fast-forward). Bitbucket maps ` + "`--rebase`" + ` to a fast-forward merge.
+
+### **one pr status** [--watch]
+Show the CI checks (GitHub check runs and statuses, GitLab pipeline jobs),
+approvals, requested changes, mergeability and unresolved threads of the
+current branch's PR/MR. ` + "`--watch`" + ` refreshes until the checks finish and
+exits non-zero if a required check failed:
+
+` + "```bash" + `
+one pr status --watch && one pr ready
+` + "```" + `
+
+### **one pr list** [--json] [--timeout 20s]
+List the open PRs/MRs you authored or were asked to review in every
+configured project, grouped by project with their CI and review state.
+Projects are queried in parallel; one that fails or exceeds ` + "`--timeout`" + `
+shows an error without holding up the rest. ` + "`--json`" + ` prints the same
+data for scripts:
+
+` + "```bash" + `
+one pr list --json | jq '.[].pull_requests[] | select(.role == "reviewer")'
 ` + "```" + `
 
 ---
 
-### **one ticket** <TICKET-ID>
+### **one tickets** [--all]
+List the open tickets assigned to you and pick one to start, with type-to-filter.
+Jira tickets come from the ` + "`board_id`" + ` project's active sprint (or all open
+tickets when there is none); ` + "`--all`" + ` skips the sprint filter. GitHub Issues and
+Linear list every open issue assigned to you.
+
+---
+
+### **one ticket** [TICKET-ID] [--show]
 Open a ticket in your browser.
 
 **Example:**
 ` + "```bash" + `
 one ticket PROJ-1234
+one ticket PROJ-1234 --show   # read it in the terminal
+one ticket                    # the ticket of the current branch
 ` + "```" + `
 
+Without an ID, the ticket is read from the branch with ` + "`branch_patterns.ticket_id`" + `.
+When no pattern is set, common branch names are recognised: ` + "`ABC-123-fix-login`" + `
+or ` + "`feature/ENG-42`" + ` for Jira and Linear, ` + "`123-fix-login`" + ` or ` + "`gh-123`" + ` for GitHub.
+Branch type words are not taken for ticket keys, so ` + "`hotfix-123`" + ` and
+` + "`release-1.2`" + ` have no ticket.
+
+` + "`--show`" + ` renders the description, status, assignee, priority, sub-tasks, linked
+pull requests and latest comments in the terminal. It is the default when no
+browser is configured. Jira wiki markup and ADF are converted to Markdown.
+
+---
+
+### **one ui**
+Full-screen dashboard with the current project, branch, linked ticket, PR
+status and recent branches. Keys: **s** start a task, **p** create or open
+the PR, **t** open the ticket, **↑/↓** and **enter** check out a recent
+branch, **w** switch project, **r** refresh, **q** quit.
+
+---
+
+### **one login** [service] / **one logout** [service]
+Store or remove a token for the current project. Services: github, gitlab,
+bitbucket, jira, linear.
+
+### **one status**
+Show where each project's tokens come from and who they authenticate as.
+
 ---
 
 ### **one config list**
 List all configured projects.
 
-### **one config show**
-Show current project configuration.
+### **one config show** [--resolved]
+Show current project configuration. ` + "`--resolved`" + ` annotates each value with
+the layer it came from.
+
+### **one config which**
+Explain which project applies to the current directory and why.
+
+### **one config validate**
+Check every project file for unknown keys, missing settings, invalid
+patterns and hooks, unknown template placeholders and overlapping paths.
+Problems are reported with file and line numbers.
 
 ---
 
 ## 🎨 Template Variables
 
-Use these in your PR templates:
+PR titles and bodies are Go templates. The short placeholders still work:
+
+| Variable | Template field | Example |
+|----------|----------------|---------|
+| ` + "`{ticket_id}`" + ` | ` + "`{{.Ticket.ID}}`" + ` | PROJ-1234 |
+| ` + "`{ticket_title}`" + ` | ` + "`{{.Ticket.Title}}`" + ` | Add user authentication |
+| ` + "`{ticket_url}`" + ` | ` + "`{{.Ticket.URL}}`" + ` | https://jira.../PROJ-1234 |
+| ` + "`{ticket_state}`" + ` | ` + "`{{.Ticket.State}}`" + ` | In Progress |
+| ` + "`{ticket_assignee}`" + ` | ` + "`{{.Ticket.Assignee}}`" + ` | Jane Doe |
+| ` + "`{closes}`" + ` | ` + "`{{.Closes}}`" + ` | Closes #123 (GitHub Issues) |
+| ` + "`{branch_name}`" + ` | ` + "`{{.Branch}}`" + ` | proj-1234-add-feature |
+| ` + "`{base_branch}`" + ` | ` + "`{{.BaseBranch}}`" + ` | main |
+| ` + "`{author}`" + ` | ` + "`{{.Author.Name}}`" + ` | John Doe |
+| ` + "`{email}`" + ` | ` + "`{{.Author.Email}}`" + ` | ` + "`john@example.com`" + ` |
+| ` + "`{date}`" + ` | ` + "`{{.Date}}`" + ` | 2025-10-06 |
+
+More fields: ` + "`.Ticket.Type`" + `, ` + "`.Ticket.Priority`" + `, ` + "`.Ticket.Labels`" + `,
+` + "`.Commits`" + ` (` + "`.Hash`" + `, ` + "`.ShortHash`" + `, ` + "`.Subject`" + `, ` + "`.Body`" + `, ` + "`.Author`" + `),
+` + "`.Files`" + ` (` + "`.Path`" + `, ` + "`.Status`" + `, ` + "`.Additions`" + `, ` + "`.Deletions`" + `) and
+` + "`.Stats`" + ` (` + "`.Files`" + `, ` + "`.Additions`" + `, ` + "`.Deletions`" + `).
+Commits are those between the base branch and HEAD, merge commits excluded.
+` + "`.Summary`" + ` counts them by Conventional Commit type ("2 features and 1 fix")
+and ` + "`.FilesByDirectory`" + ` groups ` + "`.Files`" + ` (` + "`.Dir`" + `, ` + "`.Files`" + `).
+
+Helpers: ` + "`upper`" + `, ` + "`lower`" + `, ` + "`trim`" + `, ` + "`truncate N`" + `, ` + "`slug`" + `,
+` + "`join SEP`" + ` and ` + "`default VALUE`" + `.
+
+` + "```yaml" + `
+templates:
+  pr_title: "{{ .Ticket.Type | default \"feat\" | lower }}: {{ .Ticket.Title | truncate 60 }}"
+  pr_body: |
+    ## Changes
+    {{ range .Commits }}- {{ .Subject }}
+    {{ end }}
+    {{ if .Ticket.Labels }}Labels: {{ .Ticket.Labels | join ", " }}{{ end }}
+    {{ .Stats.Files }} files, +{{ .Stats.Additions }} -{{ .Stats.Deletions }}
+` + "```" + `
 
-| Variable | Example |
-|----------|---------|
-| ` + "`{ticket_id}`" + ` | PROJ-1234 |
-| ` + "`{branch_name}`" + ` | proj-1234-add-feature |
-| ` + "`{ticket_url}`" + ` | https://jira.../PROJ-1234 |
-| ` + "`{author}`" + ` | John Doe |
-| ` + "`{email}`" + ` | john@example.com |
-| ` + "`{date}`" + ` | 2025-10-06 |
-| ` + "`{base_branch}`" + ` | main |
+The body can also come from a file with ` + "`pr_body_file`" + ` (relative to the
+repository). Without a body template, one uses the repository's
+` + "`.github/pull_request_template.md`" + ` (or GitLab's
+` + "`.gitlab/merge_request_templates/Default.md`" + `) when present; if it is not a
+valid template, only its ` + "`{placeholders}`" + ` are filled in. Otherwise one writes
+a default body: the commit type summary, the commit subjects and the changed
+files grouped by directory.
 
 ---
 
@@ -187,6 +341,14 @@ ticket:
   base_url: https://acme.atlassian.net
   jira:
     board_id: ACME
+    workflow:
+      start:                     # one start
+        transition: In Progress
+        assign: true
+      pr:                        # one pr, when the PR is created
+        transition: In Review
+        link_pr: true
+        comment: "PR opened: {pr_url}"
 
 templates:
   pr_title: "[{ticket_id}] {branch_name}"
@@ -201,6 +363,42 @@ branch_patterns:
   ticket_id: "^([A-Z]+-\\d+)"
 ` + "```" + `
 
+The Jira ` + "`workflow`" + ` steps are optional. ` + "`transition`" + ` takes a transition or
+status name, ` + "`assign`" + ` assigns the ticket to you, ` + "`link_pr`" + ` adds the PR as a
+remote link and ` + "`comment`" + ` is rendered like the PR templates, with
+` + "`{pr_url}`" + ` (` + "`{{.PRURL}}`" + `) set in the ` + "`pr`" + ` step. A step that fails only warns.
+
+### Layered Configuration
+
+Settings are resolved from these layers, later ones winning:
+
+1. Built-in defaults (` + "`remote: origin`" + `, ` + "`base_branch: main`" + `)
+2. ` + "`~/.config/one/config.yml`" + ` defaults shared by all projects
+3. The project file in ` + "`~/.config/one/projects/`" + `
+4. A ` + "`.one.yml`" + ` in the repository, limited to ` + "`git.base_branch`" + `,
+   ` + "`git.default_draft`" + `, ` + "`git.pr`" + `, ` + "`templates`" + ` and ` + "`branch_patterns`" + `
+5. Environment variables: ` + "`ONE_GIT_PROVIDER`" + `, ` + "`ONE_GIT_REMOTE`" + `,
+   ` + "`ONE_GIT_BASE_BRANCH`" + `, ` + "`ONE_GIT_DEFAULT_DRAFT`" + `, ` + "`ONE_BROWSER_TYPE`" + `,
+   ` + "`ONE_BROWSER_PROFILE`" + `, ` + "`ONE_TICKET_SYSTEM`" + `, ` + "`ONE_TICKET_BASE_URL`" + `
+
+A cloned repository cannot set hooks, API URLs, certificates or token
+variables through ` + "`.one.yml`" + `; ` + "`one config validate`" + ` reports such keys and
+they are ignored. A ` + "`templates.pr_body_file`" + ` set there must be a path inside the
+repository.
+
+` + "```yaml" + `
+# ~/.config/one/config.yml
+version: 1
+defaults:
+  browser:
+    type: firefox
+  git:
+    remote: origin
+    base_branch: develop
+` + "```" + `
+
+Run ` + "`one config show --resolved`" + ` to see where each value comes from.
+
 ---
 
 ## 🔒 Authentication
@@ -211,11 +409,46 @@ Credentials are stored securely in your system keyring:
 - **Linux**: Secret Service (gnome-keyring/kwallet)
 - **Windows**: Credential Manager
 
+**Managing Credentials:**
+` + "```bash" + `
+one login            # authenticate with the project's git provider
+one login jira       # or a specific service
+one logout linear    # remove a stored token
+one status           # show and verify credentials for every project
+` + "```" + `
+
+Tokens are looked up in the keyring first, then in the environment.
+
+**Jira authentication** is chosen with ` + "`ticket.jira.auth`" + `:
+
+` + "```yaml" + `
+ticket:
+  system: jira
+  base_url: https://acme.atlassian.net
+  jira:
+    auth: cloud                  # cloud (default), pat or oauth
+    email: you@acme.com          # cloud: the account of the API token
+    api_version: 3               # 2 (default) or 3, Cloud only
+    oauth:                       # oauth: an OAuth 2.0 (3LO) app
+      client_id: abc123
+      client_secret_env: JIRA_CLIENT_SECRET
+` + "```" + `
+
+- **cloud**: ` + "`one login jira`" + ` asks for your email and an API token
+- **pat**: Jira Data Center/Server personal access token, sent as a Bearer token
+- **oauth**: ` + "`one login jira`" + ` opens the Atlassian consent page and refreshes
+  the token when it expires. Add ` + "`http://localhost:8735/callback`" + ` (or
+  ` + "`callback_port`" + `) as the app's callback URL.
+
+With ` + "`api_version: 3`" + ` descriptions and comments use the Atlassian Document
+Format, which is converted to and from Markdown.
+
 **Environment Variable Fallback:**
 ` + "```bash" + `
 export GITHUB_TOKEN="ghp_xxxxxxxxxxxx"
 export GITLAB_TOKEN="glpat-xxxxxxxxxxxx"
 export JIRA_TOKEN="email@example.com:api_token"
+export LINEAR_API_KEY="lin_api_xxxxxxxxxxxx"
 ` + "```" + `
 
 ---
@@ -229,8 +462,8 @@ export JIRA_TOKEN="email@example.com:api_token"
 
 ### Ticket Systems
 - ✓ Jira (with API integration)
-- ✓ Linear (URL generation)
-- ✓ GitHub Issues (URL generation)
+- ✓ Linear (with API integration)
+- ✓ GitHub Issues (with API integration)
 
 ### Browsers
 - ✓ Chrome (with profile support)
@@ -252,6 +485,19 @@ You can have different configurations for different projects:
 ` + "```" + `
 
 One CLI automatically detects which project you're in based on the current directory.
+When paths nest, the most specific one wins. Paths may use ` + "`~`" + ` and globs, and
+projects can also match on the repository's remote URL:
+
+` + "```yaml" + `
+project:
+  name: "Acme Corp"
+  paths:
+    - "~/clients/acme/*"
+  remotes:
+    - github.com/acme-corp
+` + "```" + `
+
+Run ` + "`one config which`" + ` to see which project matched and why.
 
 ### Browser Profiles
 Keep work and personal browsing separate by using different browser profiles:
@@ -262,6 +508,21 @@ browser:
   profile: "Work Profile"
 ` + "```" + `
 
+### Self-Hosted GitHub Enterprise / GitLab
+Point the provider block at your instance's API and trust its CA:
+
+` + "```yaml" + `
+git:
+  provider: gitlab
+  gitlab:
+    project_id: 42
+    base_url: https://git.acme.internal/api/v4
+    hosts: ["ssh.git.acme.internal"]
+    ca_cert: ~/.config/one/acme-ca.pem
+` + "```" + `
+
+Hosts listed in any project are also used to detect the provider during ` + "`one init`" + `.
+
 ### Custom Templates
 Customize PR templates per project:
 
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -39,7 +41,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	var detectedRemote *git.RemoteInfo
 	var detectedBaseBranch string
 
-	remoteInfo, err := git.DetectRemote("origin")
+	remoteInfo, err := git.DetectRemote("origin", config.KnownProviderHosts())
 	if err == nil {
 		detectedRemote = remoteInfo
 		fmt.Printf("✓ Detected Git remote: %s (%s/%s)\n\n", remoteInfo.Provider, remoteInfo.Owner, remoteInfo.Repo)
@@ -65,12 +67,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 		workspace    string
 		repoSlug     string
 		tokenEnv     string
+		apiBaseURL   string
+		caCert       string
 		browser      string
 		profile      string
 		hasTicket    bool
 		ticketSystem string
 		ticketURL    string
 		boardID      string
+		ticketEnv    string
 		pattern      string = "^([A-Z]+-\\d+)"
 		authNow      bool
 	)
@@ -247,6 +252,30 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Self-hosted GitHub Enterprise / GitLab instances need their API root
+	if (provider == "github" || provider == "gitlab") && detectedRemote != nil && !git.IsPublicHost(detectedRemote.Host) {
+		apiBaseURL = defaultAPIBaseURL(provider, detectedRemote.Host)
+
+		selfHostedForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("API Base URL").
+					Description(fmt.Sprintf("Self-hosted instance detected at %s", detectedRemote.Host)).
+					Value(&apiBaseURL),
+
+				huh.NewInput().
+					Title("CA Bundle").
+					Description("Optional PEM file for instances using a private CA").
+					Value(&caCert).
+					Placeholder("~/.config/one/acme-ca.pem"),
+			),
+		)
+
+		if err := selfHostedForm.Run(); err != nil {
+			return err
+		}
+	}
+
 	// Browser and ticket configuration
 	
 	// Detect available browser profiles
@@ -417,6 +446,28 @@ func runInit(cmd *cobra.Command, args []string) error {
 				return err
 			}
 		}
+
+		// GitHub issue branches are named "123-description"
+		if ticketSystem == "github" {
+			pattern = "^(\\d+)-"
+		}
+
+		// Linear-specific configuration
+		if ticketSystem == "linear" {
+			ticketEnv = "LINEAR_API_KEY"
+			linearForm := huh.NewForm(
+				huh.NewGroup(
+					huh.NewInput().
+						Title("Linear API Key Environment Variable").
+						Value(&ticketEnv).
+						Placeholder("LINEAR_API_KEY"),
+				),
+			)
+
+			if err := linearForm.Run(); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Build configuration
@@ -444,12 +495,18 @@ func runInit(cmd *cobra.Command, args []string) error {
 			Owner:    owner,
 			Repo:     repo,
 			TokenEnv: tokenEnv,
+			BaseURL:  apiBaseURL,
+			Hosts:    selfHostedHosts(detectedRemote, apiBaseURL),
+			CACert:   caCert,
 		}
 	case "gitlab":
 		pid, _ := strconv.Atoi(projectID)
 		cfg.Git.GitLab = &config.GitLabConfig{
 			ProjectID: pid,
 			TokenEnv:  tokenEnv,
+			BaseURL:   apiBaseURL,
+			Hosts:     selfHostedHosts(detectedRemote, apiBaseURL),
+			CACert:    caCert,
 		}
 	case "bitbucket":
 		cfg.Git.Bitbucket = &config.BitbucketConfig{
@@ -472,6 +529,12 @@ func runInit(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if ticketSystem == "linear" {
+			cfg.Ticket.Linear = &config.LinearConfig{
+				TokenEnv: ticketEnv,
+			}
+		}
+
 		if pattern != "" {
 			cfg.BranchPatterns = &config.BranchPatterns{
 				TicketID: pattern,
@@ -504,7 +567,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		_, err := auth.GitHubDeviceFlow(name)
 		if err != nil {
 			fmt.Printf("⚠️  Authentication failed: %v\n", err)
-			fmt.Printf("You can authenticate later by setting the %s environment variable.\n\n", tokenEnv)
+			fmt.Printf("You can authenticate later with 'one login' or by setting the %s environment variable.\n\n", tokenEnv)
 		} else {
 			fmt.Println()
 			fmt.Println(successStyle.Render("✓ Successfully authenticated with GitHub!"))
@@ -512,7 +575,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	} else if !authNow && provider == "github" {
 		fmt.Println("Authentication skipped.")
-		fmt.Println("To authenticate later, set the environment variable:")
+		fmt.Println("To authenticate later, run 'one login' or set the environment variable:")
 		fmt.Printf("  export %s=\"your-token-here\"\n", tokenEnv)
 		fmt.Println()
 	}
@@ -547,3 +610,27 @@ func getDetectedDesc(remote *git.RemoteInfo, field string) string {
 
 	return ""
 }
+
+// defaultAPIBaseURL guesses the API root of a self-hosted instance
+func defaultAPIBaseURL(provider, host string) string {
+	switch provider {
+	case "github":
+		return "https://" + host + "/api/v3"
+	case "gitlab":
+		return "https://" + host + "/api/v4"
+	default:
+		return ""
+	}
+}
+
+// selfHostedHosts records the remote host when it differs from the API host,
+// e.g. ssh.git.acme.internal vs git.acme.internal
+func selfHostedHosts(remote *git.RemoteInfo, apiBaseURL string) []string {
+	if remote == nil || apiBaseURL == "" || git.IsPublicHost(remote.Host) {
+		return nil
+	}
+	if u, err := url.Parse(apiBaseURL); err == nil && strings.EqualFold(u.Hostname(), remote.Host) {
+		return nil
+	}
+	return []string{remote.Host}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"one/internal/api"
+	"one/internal/auth"
+	"one/internal/config"
+	"one/internal/git"
+	"one/internal/template"
+)
+
+// Jira workflow steps, see config.JiraWorkflow
+const (
+	jiraStepStart = "start"
+	jiraStepPR    = "pr"
+)
+
+// jiraWorkflowStep returns the configured Jira workflow step, or nil when
+// the project does not use one
+func jiraWorkflowStep(cfg *config.ProjectConfig, name string) *config.JiraStep {
+	if cfg.Ticket == nil || cfg.Ticket.System != "jira" || cfg.Ticket.Jira == nil || cfg.Ticket.Jira.Workflow == nil {
+		return nil
+	}
+
+	switch name {
+	case jiraStepStart:
+		return cfg.Ticket.Jira.Workflow.Start
+	case jiraStepPR:
+		return cfg.Ticket.Jira.Workflow.PR
+	default:
+		return nil
+	}
+}
+
+// runJiraStep transitions, assigns, links and comments on the ticket as a
+// workflow step asks. The branch or PR already exists at this point, so
+// failures are reported as warnings rather than errors.
+func runJiraStep(cfg *config.ProjectConfig, name string, repo *git.Repository, branch, ticketID string, pr *api.PullRequest) {
+	step := jiraWorkflowStep(cfg, name)
+	if step == nil || ticketID == "" {
+		return
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	warn := func(action string, err error) {
+		fmt.Println(warnStyle.Render(fmt.Sprintf("  ! Could not %s: %v", action, explainAPIError("jira", err))))
+	}
+
+	fmt.Printf("Updating %s in Jira...\n", ticketID)
+
+	token, err := getTicketToken(cfg)
+	if err != nil {
+		warn("update the ticket", err)
+		fmt.Println()
+		return
+	}
+	client := newJiraClient(cfg, token)
+
+	if step.Transition != "" {
+		// Jira only offers transitions away from the current status
+		if issue, err := client.GetIssue(ticketID); err == nil && strings.EqualFold(issue.Status, step.Transition) {
+			fmt.Println(successStyle.Render("  ✓ Already " + issue.Status))
+		} else if err := client.TransitionIssue(ticketID, step.Transition); err != nil {
+			warn("move it to "+step.Transition, err)
+		} else {
+			fmt.Println(successStyle.Render("  ✓ Moved to " + step.Transition))
+		}
+	}
+
+	if step.Assign {
+		user, err := client.CurrentUser()
+		if err == nil {
+			err = client.AssignIssue(ticketID, user)
+		}
+		if err != nil {
+			warn("assign it", err)
+		} else {
+			fmt.Println(successStyle.Render("  ✓ Assigned to " + user.Name))
+		}
+	}
+
+	if step.LinkPR && pr != nil {
+		title := fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title)
+		if err := client.AddRemoteLink(ticketID, pr.URL, title); err != nil {
+			warn("link the PR", err)
+		} else {
+			fmt.Println(successStyle.Render("  ✓ Linked " + pr.URL))
+		}
+	}
+
+	if step.Comment != "" {
+		data := buildPRData(cfg, repo, branch, ticketID)
+		if pr != nil {
+			data.PRURL = pr.URL
+		}
+
+		comment, err := template.Execute(step.Comment, data)
+		if err == nil {
+			err = client.AddComment(ticketID, strings.TrimSpace(comment))
+		}
+		if err != nil {
+			warn("comment", err)
+		} else {
+			fmt.Println(successStyle.Render("  ✓ Commented"))
+		}
+	}
+
+	fmt.Println()
+}
+
+// jiraAuthMode returns the project's Jira authentication mode
+func jiraAuthMode(cfg *config.ProjectConfig) string {
+	if cfg.Ticket != nil && cfg.Ticket.Jira != nil && cfg.Ticket.Jira.Auth != "" {
+		return cfg.Ticket.Jira.Auth
+	}
+	return api.JiraAuthCloud
+}
+
+// jiraOAuthApp reads the OAuth app settings, with the client secret taken
+// from its environment variable
+func jiraOAuthApp(cfg *config.ProjectConfig) (auth.AtlassianApp, error) {
+	oauth := cfg.Ticket.Jira.OAuth
+	if oauth == nil || oauth.ClientID == "" || oauth.ClientSecretEnv == "" {
+		return auth.AtlassianApp{}, fmt.Errorf("ticket.jira.oauth.client_id and client_secret_env are required for OAuth")
+	}
+
+	secret := os.Getenv(oauth.ClientSecretEnv)
+	if secret == "" {
+		return auth.AtlassianApp{}, fmt.Errorf("%s is not set", oauth.ClientSecretEnv)
+	}
+
+	return auth.AtlassianApp{
+		ClientID:     oauth.ClientID,
+		ClientSecret: secret,
+		CallbackPort: oauth.CallbackPort,
+	}, nil
+}
+
+// jiraOAuthToken returns the stored OAuth access token, refreshing it when
+// it has expired
+func jiraOAuthToken(cfg *config.ProjectConfig) (string, error) {
+	token, err := auth.GetToken("jira", cfg.Project.Name)
+	if err != nil {
+		return "", fmt.Errorf("not authenticated with jira, run 'one login jira'")
+	}
+
+	if token.Expired() {
+		app, err := jiraOAuthApp(cfg)
+		if err != nil {
+			return "", err
+		}
+		token, err = auth.RefreshAtlassianToken(app, token, cfg.Project.Name)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return token.AccessToken, nil
+}
@@ -3,13 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"one/internal/api"
-	"one/internal/auth"
 	"one/internal/browser"
 	"one/internal/config"
 	"one/internal/git"
@@ -24,30 +24,37 @@ var prCmd = &cobra.Command{
 	RunE:  runPR,
 }
 
+var prReadyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "Mark the current branch's draft PR as ready for review",
+	Args:  cobra.NoArgs,
+	RunE:  runPRReady,
+}
+
 func init() {
 	rootCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prReadyCmd)
 	prCmd.Flags().StringP("title", "t", "", "Custom PR title")
 	prCmd.Flags().StringP("description", "d", "", "Custom PR description")
 	prCmd.Flags().Bool("no-browser", false, "Skip opening browser")
+	prCmd.Flags().Bool("draft", false, "Create the PR as a draft (defaults to git.default_draft)")
+	prCmd.Flags().Bool("refresh", false, "Re-render title and body from templates when the PR already exists")
+	prCmd.Flags().BoolP("yes", "y", false, "Skip the review step and submit right away")
+	prCmd.Flags().StringSlice("reviewer", nil, "Request a review from a user (or org/team on GitHub); repeatable")
+	prCmd.Flags().StringSlice("assignee", nil, "Assign a user; repeatable")
+	prCmd.Flags().StringSlice("label", nil, "Add a label; repeatable")
+	prCmd.Flags().String("milestone", "", "Add to a milestone, by title")
 }
 
-type prModel struct {
-	cfg         *config.ProjectConfig
-	repo        *git.Repository
+// prOptions holds the command-line options for PR creation
+type prOptions struct {
 	customTitle string
 	customDesc  string
 	noBrowser   bool
-	status      string
-	err         error
-	done        bool
-	prURL       string
-	branchName  string
-	ticketID    string
-}
-
-type prCreatedMsg struct {
-	url string
-	err error
+	draft       bool
+	refresh     bool
+	yes         bool
+	meta        api.PullRequestMetadata
 }
 
 func runPR(cmd *cobra.Command, args []string) error {
@@ -61,6 +68,20 @@ func runPR(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// --draft overrides git.default_draft in both directions
+	draft := cfg.Git.DefaultDraft
+	if cmd.Flags().Changed("draft") {
+		draft, _ = cmd.Flags().GetBool("draft")
+	}
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	var meta api.PullRequestMetadata
+	meta.Reviewers, _ = cmd.Flags().GetStringSlice("reviewer")
+	meta.Assignees, _ = cmd.Flags().GetStringSlice("assignee")
+	meta.Labels, _ = cmd.Flags().GetStringSlice("label")
+	meta.Milestone, _ = cmd.Flags().GetString("milestone")
+
 	// Open repository
 	repo, err := git.OpenRepository()
 	if err != nil {
@@ -80,43 +101,18 @@ func runPR(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	m := &prModel{
-		cfg:         cfg,
-		repo:        repo,
+	return runPRActual(cfg, repo, branch, prOptions{
 		customTitle: customTitle,
 		customDesc:  customDesc,
 		noBrowser:   noBrowser,
-		status:      "Creating pull request...",
-		branchName:  branch,
-	}
-
-	p := tea.NewProgram(m)
-	finalModel, err := p.Run()
-	if err != nil {
-		return err
-	}
-
-	fm := finalModel.(*prModel)
-	if fm.err != nil {
-		if fm.err.Error() == "HOOKS_FIRST" {
-			// This was just to get branch info, now actually create PR
-			return runPRActual(cfg, repo, branch, customTitle, customDesc, noBrowser)
-		}
-		return fm.err
-	}
-
-	// Run after_pr hooks (if any)
-	if cfg.Hooks != nil && len(cfg.Hooks.AfterPR) > 0 {
-		if err := hooks.ExecuteHooks(cfg.Hooks.AfterPR, "after_pr"); err != nil {
-			// Don't fail the whole command if after hooks fail
-			fmt.Printf("Warning: after_pr hooks failed: %v\n", err)
-		}
-	}
-
-	return nil
+		draft:       draft,
+		refresh:     refresh,
+		yes:         yes,
+		meta:        meta,
+	})
 }
 
-func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch, customTitle, customDesc string, noBrowser bool) error {
+func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch string, opts prOptions) error {
 	// Check if clean
 	clean, err := repo.IsClean()
 	if err != nil {
@@ -135,14 +131,71 @@ func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch, custom
 		}
 	}
 
+	// Get token
+	token, err := getTokenForPR(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Look for an open PR first so re-running one pr updates instead of failing
+	existing, err := findProviderPR(cfg, token, branch)
+	if err != nil {
+		return fmt.Errorf("failed to look up existing pull request: %w", explainAPIError(cfg.Git.Provider, err))
+	}
+
+	// Render and review the title and body before anything is pushed. An
+	// existing PR keeps its text unless asked to change it.
+	sub := &prSubmission{Base: cfg.Git.BaseBranch}
+	if existing == nil {
+		sub.PullRequestMetadata, sub.SuggestedReviewers = prMetadata(cfg, repo, token, opts.meta)
+	}
+	updateText := existing == nil || opts.refresh || opts.customTitle != "" || opts.customDesc != ""
+	if updateText {
+		sub.Title, sub.Body, err = renderPRText(cfg, repo, branch, ticketID, opts)
+		if err != nil {
+			return err
+		}
+		if existing != nil && !opts.refresh {
+			sub.Title, sub.Body = existing.Title, existing.Body
+			if opts.customTitle != "" {
+				sub.Title = opts.customTitle
+			}
+			if opts.customDesc != "" {
+				sub.Body = opts.customDesc
+			}
+		}
+
+		if !opts.yes && isInteractive() {
+			ok, err := reviewPR(cfg, repo, token, sub, existing != nil)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("pull request cancelled")
+			}
+		}
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+
 	fmt.Println()
-	fmt.Println("Creating pull request...")
+	if existing != nil {
+		fmt.Println("Updating pull request...")
+	} else {
+		fmt.Println("Creating pull request...")
+	}
 	fmt.Println()
 	fmt.Printf("  Project: %s\n", cfg.Project.Name)
 	fmt.Printf("  Branch: %s\n", branch)
 	if ticketID != "" {
 		fmt.Printf("  Ticket ID: %s\n", ticketID)
 	}
+	if existing == nil && sub.Base != cfg.Git.BaseBranch {
+		fmt.Printf("  Base: %s\n", sub.Base)
+	}
+	if opts.draft && existing == nil {
+		fmt.Println("  Draft: yes")
+	}
 	fmt.Println()
 
 	// Push to remote
@@ -150,83 +203,55 @@ func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch, custom
 	if err := repo.Push(cfg.Git.Remote, branch); err != nil {
 		return fmt.Errorf("failed to push: %w", err)
 	}
-	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("  ✓ Pushed to " + cfg.Git.Remote))
+	fmt.Println(successStyle.Render("  ✓ Pushed to " + cfg.Git.Remote))
 	fmt.Println()
 
-	// Build template context
-	ctx := template.Context{
-		"ticket_id":   ticketID,
-		"branch_name": branch,
-		"base_branch": cfg.Git.BaseBranch,
-		"date":        template.GetCurrentDate(),
-	}
-
-	if cfg.Ticket != nil && ticketID != "" {
-		ctx["ticket_url"] = template.BuildTicketURL(cfg.Ticket.System, cfg.Ticket.BaseURL, ticketID)
-	}
-
-	// Generate title and body
-	title := customTitle
-	if title == "" && cfg.Templates != nil {
-		title = template.Render(cfg.Templates.PRTitle, ctx)
-	}
-	if title == "" {
-		title = branch
-	}
-
-	body := customDesc
-	if body == "" && cfg.Templates != nil {
-		body = template.Render(cfg.Templates.PRBody, ctx)
-	}
-
-	// Get token
-	token, err := getTokenForPR(cfg)
-	if err != nil {
-		return err
-	}
-
-	// Create PR based on provider
-	fmt.Println("Creating PR...")
 	var prURL string
-	switch cfg.Git.Provider {
-	case "github":
-		if cfg.Git.GitHub == nil {
-			return fmt.Errorf("GitHub configuration missing")
+	var created *api.PullRequest
+	if existing != nil {
+		prURL = existing.URL
+
+		if updateText {
+			fmt.Println("Updating PR...")
+			if err := updateProviderPR(cfg, token, existing, sub.Title, sub.Body); err != nil {
+				return explainAPIError(cfg.Git.Provider, err)
+			}
+			fmt.Println(successStyle.Render("  ✓ PR updated: " + prURL))
+		} else {
+			fmt.Println(successStyle.Render("  ✓ PR already open: " + prURL))
 		}
-		client := api.NewGitHubClient(token)
-		prURL, err = client.CreatePullRequest(
-			cfg.Git.GitHub.Owner,
-			cfg.Git.GitHub.Repo,
-			title,
-			body,
-			branch,
-			cfg.Git.BaseBranch,
-		)
-	case "gitlab":
-		if cfg.Git.GitLab == nil {
-			return fmt.Errorf("GitLab configuration missing")
+	} else {
+		// Create PR based on provider
+		fmt.Println("Creating PR...")
+		pr, err := createProviderPR(cfg, token, sub, branch, opts.draft)
+		if api.IsConflict(err) {
+			// Someone (or an earlier run) opened it in the meantime
+			if found, findErr := findProviderPR(cfg, token, branch); findErr == nil && found != nil {
+				prURL, err = found.URL, nil
+				fmt.Println(successStyle.Render("  ✓ PR already open: " + prURL))
+			}
+		} else if err == nil {
+			prURL = pr.URL
+			fmt.Println(successStyle.Render("  ✓ PR created: " + prURL))
+
+			// The PR exists at this point, so a failure here is only a warning
+			if err := applyPRMetadata(cfg, token, pr, sub); err != nil {
+				fmt.Printf("Warning: %v\n", explainAPIError(cfg.Git.Provider, err))
+			}
+			created = pr
+		}
+		if err != nil {
+			return explainAPIError(cfg.Git.Provider, err)
 		}
-		client := api.NewGitLabClient(token)
-		prURL, err = client.CreateMergeRequest(
-			cfg.Git.GitLab.ProjectID,
-			title,
-			body,
-			branch,
-			cfg.Git.BaseBranch,
-		)
-	default:
-		return fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
 	}
+	fmt.Println()
 
-	if err != nil {
-		return err
+	if created != nil {
+		runJiraStep(cfg, jiraStepPR, repo, branch, ticketID, created)
 	}
 
-	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("  ✓ PR created: " + prURL))
-	fmt.Println()
-
 	// Open in browser
-	if !noBrowser {
+	if !opts.noBrowser {
 		fmt.Println("Opening in browser...")
 		if err := browser.OpenURL(cfg.Browser.Type, cfg.Browser.Profile, prURL); err != nil {
 			// Non-fatal
@@ -234,7 +259,7 @@ func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch, custom
 		fmt.Println()
 	}
 
-	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true).Render("Done! 🚀"))
+	fmt.Println(successStyle.Bold(true).Render("Done! 🚀"))
 	fmt.Println()
 
 	// Run after_pr hooks (if any)
@@ -248,248 +273,428 @@ func runPRActual(cfg *config.ProjectConfig, repo *git.Repository, branch, custom
 	return nil
 }
 
-func getTokenForPR(cfg *config.ProjectConfig) (string, error) {
-	// Try keyring first
-	token, err := auth.GetToken(cfg.Git.Provider, cfg.Project.Name)
-	if err == nil {
-		return token.AccessToken, nil
-	}
+// renderPRText renders the PR title and body from the flags or templates
+func renderPRText(cfg *config.ProjectConfig, repo *git.Repository, branch, ticketID string, opts prOptions) (string, string, error) {
+	data := buildPRData(cfg, repo, branch, ticketID)
 
-	// Try environment variable
-	var envVar string
-	switch cfg.Git.Provider {
-	case "github":
-		if cfg.Git.GitHub != nil {
-			envVar = cfg.Git.GitHub.TokenEnv
-		}
-	case "gitlab":
-		if cfg.Git.GitLab != nil {
-			envVar = cfg.Git.GitLab.TokenEnv
-		}
-	case "bitbucket":
-		if cfg.Git.Bitbucket != nil {
-			envVar = cfg.Git.Bitbucket.TokenEnv
+	title := opts.customTitle
+	if title == "" && cfg.Templates != nil && cfg.Templates.PRTitle != "" {
+		rendered, err := template.Execute(cfg.Templates.PRTitle, data)
+		if err != nil {
+			return "", "", fmt.Errorf("pr_title: %w", err)
 		}
+		title = strings.TrimSpace(rendered)
+	}
+	if title == "" {
+		title = branch
 	}
 
-	if envVar != "" {
-		if token := os.Getenv(envVar); token != "" {
-			return token, nil
+	body := opts.customDesc
+	if body == "" {
+		bodyTemplate, fromRepo, err := prBodyTemplate(cfg, repo)
+		if err != nil {
+			return "", "", err
+		}
+		if body, err = template.Execute(bodyTemplate, data); err != nil {
+			// Repository PR templates are written for the provider's web UI
+			// and may show literal {{ }}, so only fill in {placeholders}
+			if !fromRepo {
+				return "", "", fmt.Errorf("pr_body: %w", err)
+			}
+			body = template.ExecuteLegacy(bodyTemplate, data)
 		}
+		body = strings.TrimSpace(body)
 	}
+	body = appendClosingKeyword(body, data.Closes)
 
-	return "", fmt.Errorf("not authenticated with %s", cfg.Git.Provider)
+	return title, body, nil
 }
 
-func (m *prModel) Init() tea.Cmd {
-	return m.createPR()
+// repoPRTemplates are the pull request templates GitHub, GitLab and
+// Bitbucket users keep in their repositories, in lookup order
+var repoPRTemplates = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	".gitlab/merge_request_templates/Default.md",
 }
 
-func (m *prModel) createPR() tea.Cmd {
-	return func() tea.Msg {
-		// Get current branch
-		branch, err := m.repo.CurrentBranch()
-		if err != nil {
-			return prCreatedMsg{err: err}
-		}
-		m.branchName = branch
+// prBodyTemplate returns the body template: templates.pr_body, then
+// templates.pr_body_file, then a pull request template in the repository,
+// then the built-in summary of the branch's commits and files. fromRepo
+// reports that a repository PR template was picked.
+func prBodyTemplate(cfg *config.ProjectConfig, repo *git.Repository) (string, bool, error) {
+	if cfg.Templates != nil && cfg.Templates.PRBody != "" {
+		return cfg.Templates.PRBody, false, nil
+	}
 
-		// Check if clean
-		clean, err := m.repo.IsClean()
-		if err != nil {
-			return prCreatedMsg{err: err}
+	root, err := repo.Root()
+	if err != nil {
+		return "", false, err
+	}
+
+	if cfg.Templates != nil && cfg.Templates.PRBodyFile != "" {
+		path := cfg.Templates.PRBodyFile
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
 		}
-		if !clean {
-			return prCreatedMsg{err: fmt.Errorf("working directory is not clean")}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
 		}
 
-		// Extract ticket ID
-		if m.cfg.BranchPatterns != nil && m.cfg.BranchPatterns.TicketID != "" {
-			ticketID, err := git.ParseTicketID(branch, m.cfg.BranchPatterns.TicketID)
-			if err == nil {
-				m.ticketID = ticketID
-			}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read pr_body_file: %w", err)
 		}
+		return string(data), false, nil
+	}
 
-		// Run before_pr hooks (outside of Bubble Tea to show output)
-		// We need to exit the TUI temporarily
-		return prCreatedMsg{err: fmt.Errorf("HOOKS_FIRST")}
+	for _, name := range repoPRTemplates {
+		if data, err := os.ReadFile(filepath.Join(root, name)); err == nil {
+			return string(data), true, nil
+		}
 	}
+
+	return template.DefaultBody, false, nil
 }
 
-func (m *prModel) createPRAfterHooks() tea.Cmd {
-	return func() tea.Msg {
-		branch := m.branchName
+// buildPRData collects the ticket, commit and author data for PR templates
+func buildPRData(cfg *config.ProjectConfig, repo *git.Repository, branch, ticketID string) *template.Data {
+	data := &template.Data{
+		Ticket:     template.Ticket{ID: ticketID},
+		Branch:     branch,
+		BaseBranch: cfg.Git.BaseBranch,
+		Date:       template.GetCurrentDate(),
+	}
 
-		// Push to remote
-		if err := m.repo.Push(m.cfg.Git.Remote, branch); err != nil {
-			return prCreatedMsg{err: fmt.Errorf("failed to push: %w", err)}
-		}
+	data.Author.Name, data.Author.Email = repo.Author()
 
-		// Build template context
-		ctx := template.Context{
-			"ticket_id":   m.ticketID,
-			"branch_name": branch,
-			"base_branch": m.cfg.Git.BaseBranch,
-			"date":        template.GetCurrentDate(),
-		}
+	// Commit data is optional, e.g. when the base branch is not fetched
+	if changes, err := repo.ChangesSince(cfg.Git.Remote, cfg.Git.BaseBranch); err == nil {
+		data.Commits = changes.Commits
+		data.Files = changes.Files
+		data.Stats = changes.Stats
+	}
 
-		if m.cfg.Ticket != nil && m.ticketID != "" {
-			ctx["ticket_url"] = template.BuildTicketURL(m.cfg.Ticket.System, m.cfg.Ticket.BaseURL, m.ticketID)
+	if cfg.Ticket != nil && ticketID != "" {
+		data.Ticket.URL = template.BuildTicketURL(cfg.Ticket.System, cfg.Ticket.BaseURL, ticketID)
+		data.Closes = closingKeyword(cfg, ticketID)
+
+		// Ticket details are optional, the PR is still created without them
+		if details, err := fetchTicketDetails(cfg, ticketID); err == nil {
+			data.Ticket.Title = details.Title
+			data.Ticket.Type = details.Type
+			data.Ticket.Priority = details.Priority
+			data.Ticket.State = details.State
+			data.Ticket.Assignee = details.Assignee
+			data.Ticket.Labels = details.Labels
+			if details.URL != "" {
+				data.Ticket.URL = details.URL
+			}
 		}
+	}
+
+	return data
+}
+
+// appendClosingKeyword adds the GitHub closing keyword to the PR body unless
+// the template already rendered it
+func appendClosingKeyword(body, keyword string) string {
+	if keyword == "" || strings.Contains(body, keyword) {
+		return body
+	}
+	if body == "" {
+		return keyword
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + keyword
+}
 
-		// Generate title and body
-		title := m.customTitle
-		if title == "" && m.cfg.Templates != nil {
-			title = template.Render(m.cfg.Templates.PRTitle, ctx)
+// createProviderPR opens a pull/merge request on the configured Git provider
+func createProviderPR(cfg *config.ProjectConfig, token string, sub *prSubmission, branch string, draft bool) (*api.PullRequest, error) {
+	switch cfg.Git.Provider {
+	case "github":
+		if cfg.Git.GitHub == nil {
+			return nil, fmt.Errorf("GitHub configuration missing")
 		}
-		if title == "" {
-			title = branch
+		client := newGitHubClient(cfg, token)
+		return client.CreatePullRequest(
+			cfg.Git.GitHub.Owner,
+			cfg.Git.GitHub.Repo,
+			sub.Title,
+			sub.Body,
+			branch,
+			sub.Base,
+			draft,
+		)
+	case "gitlab":
+		if cfg.Git.GitLab == nil {
+			return nil, fmt.Errorf("GitLab configuration missing")
 		}
+		client := newGitLabClient(cfg, token)
+		return client.CreateMergeRequest(
+			cfg.Git.GitLab.ProjectID,
+			sub.Title,
+			sub.Body,
+			branch,
+			sub.Base,
+			draft,
+			sub.PullRequestMetadata,
+		)
+	case "bitbucket":
+		if cfg.Git.Bitbucket == nil {
+			return nil, fmt.Errorf("Bitbucket configuration missing")
+		}
+		client := newBitbucketClient(cfg, token)
+		return client.CreatePullRequest(
+			cfg.Git.Bitbucket.Workspace,
+			cfg.Git.Bitbucket.RepoSlug,
+			sub.Title,
+			sub.Body,
+			branch,
+			sub.Base,
+			draft,
+		)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
+	}
+}
 
-		body := m.customDesc
-		if body == "" && m.cfg.Templates != nil {
-			body = template.Render(m.cfg.Templates.PRBody, ctx)
-		}
+// applyPRMetadata requests reviewers and sets assignees, labels and the
+// milestone on a new GitHub pull request. GitLab takes them when the merge
+// request is created.
+func applyPRMetadata(cfg *config.ProjectConfig, token string, pr *api.PullRequest, sub *prSubmission) error {
+	meta := sub.PullRequestMetadata
+	if meta.IsEmpty() {
+		return nil
+	}
 
-		// Get token
-		token, err := m.getToken()
-		if err != nil {
-			return prCreatedMsg{err: err}
-		}
+	switch cfg.Git.Provider {
+	case "github":
+		client := newGitHubClient(cfg, token)
+		owner, repo := cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo
 
-		// Create PR based on provider
-		var prURL string
-		switch m.cfg.Git.Provider {
-		case "github":
-			if m.cfg.Git.GitHub == nil {
-				return prCreatedMsg{err: fmt.Errorf("GitHub configuration missing")}
+		var failed []string
+		if len(meta.Reviewers) > 0 {
+			if err := client.RequestReviewers(owner, repo, pr.Number, meta.Reviewers); err != nil {
+				failed = append(failed, fmt.Sprintf("request reviewers: %v", err))
 			}
-			client := api.NewGitHubClient(token)
-			prURL, err = client.CreatePullRequest(
-				m.cfg.Git.GitHub.Owner,
-				m.cfg.Git.GitHub.Repo,
-				title,
-				body,
-				branch,
-				m.cfg.Git.BaseBranch,
-			)
-		case "gitlab":
-			if m.cfg.Git.GitLab == nil {
-				return prCreatedMsg{err: fmt.Errorf("GitLab configuration missing")}
+		}
+		if len(meta.Assignees) > 0 {
+			if err := client.AddAssignees(owner, repo, pr.Number, meta.Assignees); err != nil {
+				failed = append(failed, fmt.Sprintf("add assignees: %v", err))
 			}
-			client := api.NewGitLabClient(token)
-			prURL, err = client.CreateMergeRequest(
-				m.cfg.Git.GitLab.ProjectID,
-				title,
-				body,
-				branch,
-				m.cfg.Git.BaseBranch,
-			)
-		default:
-			return prCreatedMsg{err: fmt.Errorf("unsupported provider: %s", m.cfg.Git.Provider)}
 		}
-
-		if err != nil {
-			return prCreatedMsg{err: err}
+		if len(meta.Labels) > 0 {
+			if err := client.AddLabels(owner, repo, pr.Number, meta.Labels); err != nil {
+				failed = append(failed, fmt.Sprintf("add labels: %v", err))
+			}
+		}
+		if meta.Milestone != "" {
+			if err := client.SetMilestone(owner, repo, pr.Number, meta.Milestone); err != nil {
+				failed = append(failed, fmt.Sprintf("set milestone: %v", err))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to %s", strings.Join(failed, "; "))
 		}
+	case "gitlab":
+		// Applied when the merge request was created
+		if len(pr.Warnings) > 0 {
+			return fmt.Errorf("failed to %s", strings.Join(pr.Warnings, "; "))
+		}
+	default:
+		return fmt.Errorf("reviewers, assignees, labels and milestones are not supported on %s", cfg.Git.Provider)
+	}
+	return nil
+}
+
+// prMetadata combines the project's PR defaults with the flags. It also
+// returns the CODEOWNERS of the changed files, which are requested as
+// reviewers when git.pr.codeowners is set and suggested otherwise.
+func prMetadata(cfg *config.ProjectConfig, repo *git.Repository, token string, flags api.PullRequestMetadata) (api.PullRequestMetadata, []string) {
+	defaults := config.PRDefaults{}
+	if cfg.Git.PR != nil {
+		defaults = *cfg.Git.PR
+	}
+
+	meta := api.PullRequestMetadata{
+		Reviewers: mergeNames(defaults.Reviewers, flags.Reviewers),
+		Assignees: mergeNames(defaults.Assignees, flags.Assignees),
+		Labels:    mergeNames(defaults.Labels, flags.Labels),
+		Milestone: flags.Milestone,
+	}
+	if meta.Milestone == "" {
+		meta.Milestone = defaults.Milestone
+	}
+
+	owners := codeOwnersForBranch(cfg, repo, token)
+	if defaults.CodeOwners {
+		meta.Reviewers = mergeNames(meta.Reviewers, owners)
+	}
+
+	return meta, owners
+}
+
+// codeOwnersForBranch returns the CODEOWNERS of the files changed on the
+// branch, leaving out the author since nobody can review their own PR
+func codeOwnersForBranch(cfg *config.ProjectConfig, repo *git.Repository, token string) []string {
+	root, err := repo.Root()
+	if err != nil {
+		return nil
+	}
+	changes, err := repo.ChangesSince(cfg.Git.Remote, cfg.Git.BaseBranch)
+	if err != nil || len(changes.Files) == 0 {
+		return nil
+	}
 
-		return prCreatedMsg{url: prURL}
+	files := make([]string, 0, len(changes.Files))
+	for _, file := range changes.Files {
+		files = append(files, file.Path)
 	}
+
+	owners, err := git.CodeOwners(root, files)
+	if err != nil || len(owners) == 0 {
+		return nil
+	}
+
+	if user, err := currentUser(cfg, cfg.Git.Provider, token); err == nil {
+		var others []string
+		for _, owner := range owners {
+			if !strings.EqualFold(owner, user.Login) {
+				others = append(others, owner)
+			}
+		}
+		owners = others
+	}
+	return owners
 }
 
-func (m *prModel) getToken() (string, error) {
-	// Try keyring first
-	token, err := auth.GetToken(m.cfg.Git.Provider, m.cfg.Project.Name)
-	if err == nil {
-		return token.AccessToken, nil
+// mergeNames appends the names in extra that are not in base yet, ignoring
+// case and a leading @
+func mergeNames(base, extra []string) []string {
+	result := make([]string, 0, len(base)+len(extra))
+	for _, list := range [][]string{base, extra} {
+		for _, name := range list {
+			name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+			if name != "" && !containsFold(result, name) {
+				result = append(result, name)
+			}
+		}
 	}
+	return result
+}
 
-	// Try environment variable
-	var envVar string
-	switch m.cfg.Git.Provider {
+// findProviderPR returns the open pull/merge request for a branch, or nil
+// if the provider has none
+func findProviderPR(cfg *config.ProjectConfig, token, branch string) (*api.PullRequest, error) {
+	switch cfg.Git.Provider {
 	case "github":
-		if m.cfg.Git.GitHub != nil {
-			envVar = m.cfg.Git.GitHub.TokenEnv
+		if cfg.Git.GitHub == nil {
+			return nil, fmt.Errorf("GitHub configuration missing")
 		}
+		client := newGitHubClient(cfg, token)
+		return client.FindPullRequest(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo, branch)
 	case "gitlab":
-		if m.cfg.Git.GitLab != nil {
-			envVar = m.cfg.Git.GitLab.TokenEnv
+		if cfg.Git.GitLab == nil {
+			return nil, fmt.Errorf("GitLab configuration missing")
 		}
+		client := newGitLabClient(cfg, token)
+		return client.FindMergeRequest(cfg.Git.GitLab.ProjectID, branch)
 	case "bitbucket":
-		if m.cfg.Git.Bitbucket != nil {
-			envVar = m.cfg.Git.Bitbucket.TokenEnv
+		if cfg.Git.Bitbucket == nil {
+			return nil, fmt.Errorf("Bitbucket configuration missing")
 		}
+		client := newBitbucketClient(cfg, token)
+		return client.FindPullRequest(cfg.Git.Bitbucket.Workspace, cfg.Git.Bitbucket.RepoSlug, branch)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
 	}
+}
 
-	if envVar != "" {
-		if token := os.Getenv(envVar); token != "" {
-			return token, nil
-		}
+// updateProviderPR replaces the title and body of an existing pull/merge request
+func updateProviderPR(cfg *config.ProjectConfig, token string, pr *api.PullRequest, title, body string) error {
+	switch cfg.Git.Provider {
+	case "github":
+		client := newGitHubClient(cfg, token)
+		return client.UpdatePullRequest(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo, pr.Number, title, body)
+	case "gitlab":
+		client := newGitLabClient(cfg, token)
+		return client.UpdateMergeRequest(cfg.Git.GitLab.ProjectID, pr, title, body)
+	case "bitbucket":
+		client := newBitbucketClient(cfg, token)
+		return client.UpdatePullRequest(cfg.Git.Bitbucket.Workspace, cfg.Git.Bitbucket.RepoSlug, pr.Number, title, body)
+	default:
+		return fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
 	}
-
-	return "", fmt.Errorf("not authenticated with %s", m.cfg.Git.Provider)
 }
 
-func (m *prModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			m.done = true
-			return m, tea.Quit
-		}
+// markProviderPRReady takes a pull/merge request out of draft
+func markProviderPRReady(cfg *config.ProjectConfig, token string, pr *api.PullRequest) error {
+	switch cfg.Git.Provider {
+	case "github":
+		client := newGitHubClient(cfg, token)
+		return client.MarkReadyForReview(pr.NodeID)
+	case "gitlab":
+		client := newGitLabClient(cfg, token)
+		return client.MarkReady(cfg.Git.GitLab.ProjectID, pr)
+	case "bitbucket":
+		client := newBitbucketClient(cfg, token)
+		return client.MarkReady(cfg.Git.Bitbucket.Workspace, cfg.Git.Bitbucket.RepoSlug, pr.Number)
+	default:
+		return fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
+	}
+}
 
-	case prCreatedMsg:
-		if msg.err != nil {
-			m.err = msg.err
-			m.done = true
-			return m, tea.Quit
-		}
+func runPRReady(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
 
-		m.prURL = msg.url
+	repo, err := git.OpenRepository()
+	if err != nil {
+		return err
+	}
 
-		// Open in browser
-		if !m.noBrowser {
-			if err := browser.OpenURL(m.cfg.Browser.Type, m.cfg.Browser.Profile, msg.url); err != nil {
-				// Non-fatal, just continue
-			}
-		}
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return err
+	}
 
-		m.status = "Done!"
-		m.done = true
-		return m, tea.Quit
+	token, err := getTokenForPR(cfg)
+	if err != nil {
+		return err
 	}
 
-	return m, nil
-}
+	pr, err := findProviderPR(cfg, token, branch)
+	if err != nil {
+		return explainAPIError(cfg.Git.Provider, err)
+	}
+	if pr == nil {
+		return fmt.Errorf("no open pull request found for branch %s", branch)
+	}
 
-func (m *prModel) View() string {
-	if m.done {
-		if m.err != nil {
-			errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
-			return errorStyle.Render(fmt.Sprintf("Error: %v\n", m.err))
-		}
+	if !pr.Draft {
+		fmt.Printf("✓ %s is already ready for review\n", pr.URL)
+		return nil
+	}
 
-		successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
-		titleStyle := lipgloss.NewStyle().Bold(true)
+	if err := markProviderPRReady(cfg, token, pr); err != nil {
+		return fmt.Errorf("failed to mark pull request as ready: %w", explainAPIError(cfg.Git.Provider, err))
+	}
 
-		var s string
-		s += titleStyle.Render("Creating pull request...") + "\n\n"
-		s += fmt.Sprintf("  Project: %s\n", m.cfg.Project.Name)
-		s += fmt.Sprintf("  Branch: %s\n", m.branchName)
-		if m.ticketID != "" {
-			s += fmt.Sprintf("  Ticket ID: %s\n", m.ticketID)
-		}
-		s += "\n"
-		s += successStyle.Render("  ✓ Pushed to "+m.cfg.Git.Remote) + "\n"
-		s += successStyle.Render("  ✓ PR created: "+m.prURL) + "\n\n"
-		if !m.noBrowser {
-			s += "Opening in browser...\n\n"
-		}
-		s += successStyle.Render("Done! 🚀") + "\n"
+	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ Marked as ready for review: " + pr.URL))
+	return nil
+}
 
-		return s
+func getTokenForPR(cfg *config.ProjectConfig) (string, error) {
+	token := resolveToken(cfg, cfg.Git.Provider, gitTokenEnv(cfg))
+	if token.Source == "" {
+		return "", fmt.Errorf("not authenticated with %s", cfg.Git.Provider)
 	}
-
-	return fmt.Sprintf("%s\n\nPress Ctrl+C to cancel", m.status)
+	return token.Value, nil
 }
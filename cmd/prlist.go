@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/config"
+)
+
+var prListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your open PRs across all projects",
+	Long: `Lists the open pull/merge requests you authored or were asked to review in
+every configured project, with their CI and review state. Projects are
+queried concurrently; a slow or unreachable provider only affects its own
+project.`,
+	Args: cobra.NoArgs,
+	RunE: runPRList,
+}
+
+func init() {
+	prCmd.AddCommand(prListCmd)
+	prListCmd.Flags().Bool("json", false, "Print the result as JSON")
+	prListCmd.Flags().Duration("timeout", 20*time.Second, "Give up on a project after this long")
+}
+
+// prListProject is the outcome of listing the PRs of one project
+type prListProject struct {
+	Project      string       `json:"project"`
+	Provider     string       `json:"provider"`
+	Error        string       `json:"error,omitempty"`
+	PullRequests []prListItem `json:"pull_requests"`
+}
+
+// prListItem is a pull request in the JSON output
+type prListItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+	Base   string `json:"base"`
+	Author string `json:"author"`
+	Role   string `json:"role"`
+	Draft  bool   `json:"draft"`
+	Checks string `json:"checks,omitempty"`
+	Review string `json:"review,omitempty"`
+}
+
+func runPRList(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	projects, err := config.ListProjects()
+	if err != nil {
+		return err
+	}
+
+	if len(projects) == 0 {
+		if asJSON {
+			fmt.Println("[]")
+			return nil
+		}
+		fmt.Println("No projects configured. Run 'one init' to create one.")
+		return nil
+	}
+
+	results := make([]prListProject, len(projects))
+	var wg sync.WaitGroup
+	for i, project := range projects {
+		results[i] = prListProject{
+			Project:      project.Project.Name,
+			Provider:     project.Git.Provider,
+			PullRequests: []prListItem{},
+		}
+
+		wg.Add(1)
+		go func(result *prListProject, cfg *config.ProjectConfig) {
+			defer wg.Done()
+
+			type outcome struct {
+				prs []api.PullRequestSummary
+				err error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				prs, err := listProjectPRs(cfg)
+				done <- outcome{prs, err}
+			}()
+
+			select {
+			case out := <-done:
+				if out.err != nil {
+					result.Error = explainAPIError(cfg.Git.Provider, out.err).Error()
+					return
+				}
+				for _, pr := range out.prs {
+					result.PullRequests = append(result.PullRequests, prListItem{
+						Number: pr.Number,
+						Title:  pr.Title,
+						URL:    pr.URL,
+						Branch: pr.Head,
+						Base:   pr.Base,
+						Author: pr.Author,
+						Role:   pr.Role,
+						Draft:  pr.Draft,
+						Checks: pr.Checks,
+						Review: pr.Review,
+					})
+				}
+			case <-time.After(timeout):
+				result.Error = fmt.Sprintf("timed out after %s", timeout)
+			}
+		}(&results[i], project)
+	}
+	wg.Wait()
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	printPRList(results)
+	return nil
+}
+
+// listProjectPRs fetches the open PRs of a project that involve the user
+// the project's token belongs to
+func listProjectPRs(cfg *config.ProjectConfig) ([]api.PullRequestSummary, error) {
+	token := resolveToken(cfg, cfg.Git.Provider, gitTokenEnv(cfg))
+	if token.Source == "" {
+		return nil, fmt.Errorf("not authenticated with %s, run 'one login'", cfg.Git.Provider)
+	}
+
+	user, err := currentUser(cfg, cfg.Git.Provider, token.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Git.Provider {
+	case "github":
+		if cfg.Git.GitHub == nil {
+			return nil, fmt.Errorf("GitHub configuration missing")
+		}
+		client := newGitHubClient(cfg, token.Value)
+		return client.ListPullRequestsFor(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo, user.Login)
+	case "gitlab":
+		if cfg.Git.GitLab == nil {
+			return nil, fmt.Errorf("GitLab configuration missing")
+		}
+		client := newGitLabClient(cfg, token.Value)
+		return client.ListMergeRequestsFor(cfg.Git.GitLab.ProjectID, user.Login)
+	case "bitbucket":
+		if cfg.Git.Bitbucket == nil {
+			return nil, fmt.Errorf("Bitbucket configuration missing")
+		}
+		client := newBitbucketClient(cfg, token.Value)
+		return client.ListPullRequestsFor(cfg.Git.Bitbucket.Workspace, cfg.Git.Bitbucket.RepoSlug, user.ID)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
+	}
+}
+
+// printPRList prints one table per project
+func printPRList(results []prListProject) {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	for _, result := range results {
+		fmt.Println(titleStyle.Render(result.Project) + dimStyle.Render(" ("+result.Provider+")"))
+
+		switch {
+		case result.Error != "":
+			fmt.Println("  " + errorStyle.Render("✗ "+result.Error))
+		case len(result.PullRequests) == 0:
+			fmt.Println("  " + dimStyle.Render("No open PRs"))
+		default:
+			t := table.New().
+				Border(lipgloss.RoundedBorder()).
+				BorderStyle(dimStyle).
+				Headers("PR", "TITLE", "ROLE", "CI", "REVIEW").
+				StyleFunc(func(row, col int) lipgloss.Style {
+					if row == table.HeaderRow {
+						return headerStyle
+					}
+					return cellStyle
+				})
+			for _, pr := range result.PullRequests {
+				title := truncateText(pr.Title, 50)
+				if pr.Draft {
+					title = dimStyle.Render("[draft] ") + title
+				}
+				role := pr.Role
+				if pr.Role == api.RoleAuthor {
+					role = "mine"
+				}
+				t.Row(fmt.Sprintf("#%d", pr.Number), title, role, checksLabel(pr.Checks), reviewLabel(pr.Review))
+			}
+			fmt.Println(t.Render())
+			for _, pr := range result.PullRequests {
+				fmt.Println(dimStyle.Render(fmt.Sprintf("  #%d %s", pr.Number, pr.URL)))
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// checksLabel renders a combined CI state as a coloured symbol
+func checksLabel(state string) string {
+	switch state {
+	case api.CheckSuccess:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓")
+	case api.CheckFailure:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("✗")
+	case api.CheckPending, api.CheckRunning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("●")
+	case "":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("-")
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(state)
+	}
+}
+
+// reviewLabel renders a review decision
+func reviewLabel(review string) string {
+	switch review {
+	case api.ReviewApproved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("approved")
+	case api.ReviewChangesRequested:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("changes requested")
+	case api.ReviewRequired:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("review required")
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("-")
+	}
+}
+
+// truncateText shortens s to at most n characters, ending with an ellipsis
+func truncateText(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
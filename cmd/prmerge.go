@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/config"
+	"one/internal/git"
+	"one/internal/hooks"
+)
+
+var prMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge the current branch's PR and clean up",
+	Long: `Merges the pull/merge request for the current branch, then checks out the
+base branch, pulls it and deletes the feature branch locally and on the remote.
+
+The merge method comes from --merge, --squash or --rebase, then from
+git.pr.merge_method, and is asked for otherwise. With --auto the PR is merged
+by the provider once its checks pass, and no cleanup happens yet.
+
+On GitLab, --rebase rebases the merge request's branch first and then
+merges it with the project's merge method.`,
+	Args: cobra.NoArgs,
+	RunE: runPRMerge,
+}
+
+func init() {
+	prCmd.AddCommand(prMergeCmd)
+	prMergeCmd.Flags().Bool("merge", false, "Create a merge commit")
+	prMergeCmd.Flags().Bool("squash", false, "Squash the commits into one")
+	prMergeCmd.Flags().Bool("rebase", false, "Rebase the commits onto the base branch")
+	prMergeCmd.Flags().Bool("auto", false, "Merge automatically once checks pass")
+	prMergeCmd.Flags().Bool("keep-branch", false, "Keep the local and remote feature branch")
+	prMergeCmd.MarkFlagsMutuallyExclusive("merge", "squash", "rebase")
+}
+
+func runPRMerge(cmd *cobra.Command, args []string) error {
+	auto, _ := cmd.Flags().GetBool("auto")
+	keepBranch, _ := cmd.Flags().GetBool("keep-branch")
+
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	repo, err := git.OpenRepository()
+	if err != nil {
+		return err
+	}
+
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if branch == cfg.Git.BaseBranch {
+		return fmt.Errorf("already on the base branch %s", branch)
+	}
+
+	method, err := mergeMethod(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	token, err := getTokenForPR(cfg)
+	if err != nil {
+		return err
+	}
+
+	pr, err := findProviderPR(cfg, token, branch)
+	if err != nil {
+		return explainAPIError(cfg.Git.Provider, err)
+	}
+	if pr == nil {
+		return fmt.Errorf("no open pull request found for branch %s", branch)
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+
+	fmt.Println()
+	fmt.Printf("Merging %s (%s)...\n", pr.URL, method)
+
+	merged, err := mergeProviderPR(cfg, token, pr, method, auto)
+	if err != nil {
+		return fmt.Errorf("failed to merge: %w", explainAPIError(cfg.Git.Provider, err))
+	}
+	if !merged {
+		fmt.Println(successStyle.Render("  ✓ Auto-merge enabled, the PR merges once its checks pass"))
+		fmt.Println()
+		return nil
+	}
+	fmt.Println(successStyle.Render("  ✓ Merged into " + pr.Base))
+	fmt.Println()
+
+	if err := cleanUpAfterMerge(cfg, repo, branch, keepBranch); err != nil {
+		fmt.Println(warnStyle.Render(fmt.Sprintf("Warning: %v", err)))
+		fmt.Println()
+	}
+
+	fmt.Println(successStyle.Bold(true).Render("Done! 🎉"))
+	fmt.Println()
+
+	// Run after_merge hooks (if any)
+	if cfg.Hooks != nil && len(cfg.Hooks.AfterMerge) > 0 {
+		if err := hooks.ExecuteHooks(cfg.Hooks.AfterMerge, "after_merge"); err != nil {
+			// The merge is done, so failing hooks only warn
+			fmt.Printf("Warning: after_merge hooks failed: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeMethod picks the merge method from the flags, the project config or
+// a prompt, in that order
+func mergeMethod(cmd *cobra.Command, cfg *config.ProjectConfig) (string, error) {
+	for _, method := range []string{api.MergeMethodMerge, api.MergeMethodSquash, api.MergeMethodRebase} {
+		if set, _ := cmd.Flags().GetBool(method); set {
+			return method, nil
+		}
+	}
+
+	if cfg.Git.PR != nil && cfg.Git.PR.MergeMethod != "" {
+		return cfg.Git.PR.MergeMethod, nil
+	}
+
+	if !isInteractive() {
+		return api.MergeMethodMerge, nil
+	}
+
+	method := api.MergeMethodMerge
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("How should the PR be merged?").
+				Description("Set git.pr.merge_method to skip this question").
+				Options(
+					huh.NewOption("Create a merge commit", api.MergeMethodMerge),
+					huh.NewOption("Squash and merge", api.MergeMethodSquash),
+					huh.NewOption("Rebase and merge", api.MergeMethodRebase),
+				).
+				Value(&method),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+
+	return method, nil
+}
+
+// mergeProviderPR merges a pull/merge request, or enables auto-merge. It
+// reports whether the PR was merged right away.
+func mergeProviderPR(cfg *config.ProjectConfig, token string, pr *api.PullRequest, method string, auto bool) (bool, error) {
+	switch cfg.Git.Provider {
+	case "github":
+		client := newGitHubClient(cfg, token)
+		if auto {
+			return false, client.EnableAutoMerge(pr.NodeID, method)
+		}
+		return true, client.MergePullRequest(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo, pr.Number, method)
+	case "gitlab":
+		client := newGitLabClient(cfg, token)
+		return client.AcceptMergeRequest(cfg.Git.GitLab.ProjectID, pr.Number, method, auto)
+	case "bitbucket":
+		if auto {
+			return false, fmt.Errorf("auto-merge is not supported on Bitbucket")
+		}
+		client := newBitbucketClient(cfg, token)
+		return true, client.MergePullRequest(cfg.Git.Bitbucket.Workspace, cfg.Git.Bitbucket.RepoSlug, pr.Number, method)
+	default:
+		return false, fmt.Errorf("unsupported provider: %s", cfg.Git.Provider)
+	}
+}
+
+// cleanUpAfterMerge switches to the updated base branch and deletes the
+// merged feature branch
+func cleanUpAfterMerge(cfg *config.ProjectConfig, repo *git.Repository, branch string, keepBranch bool) error {
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+
+	clean, err := repo.IsClean()
+	if err != nil {
+		return fmt.Errorf("failed to check git status: %w", err)
+	}
+	if !clean {
+		return fmt.Errorf("working directory is not clean, staying on %s", branch)
+	}
+
+	fmt.Printf("Switching to %s...\n", cfg.Git.BaseBranch)
+	if err := repo.CheckoutBranch(cfg.Git.BaseBranch); err != nil {
+		return err
+	}
+	if err := repo.Pull(cfg.Git.Remote, cfg.Git.BaseBranch); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("  ✓ Pulled " + cfg.Git.Remote + "/" + cfg.Git.BaseBranch))
+
+	if keepBranch {
+		fmt.Println()
+		return nil
+	}
+
+	if err := repo.DeleteBranch(branch); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("  ✓ Deleted " + branch))
+
+	// The provider may already have deleted it after the merge
+	if err := repo.DeleteRemoteBranch(cfg.Git.Remote, branch); err != nil {
+		fmt.Printf("  Remote branch not deleted: %v\n", err)
+	} else {
+		fmt.Println(successStyle.Render("  ✓ Deleted " + cfg.Git.Remote + "/" + branch))
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/config"
+	"one/internal/git"
+)
+
+var prStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show CI checks and review state of the current branch's PR",
+	Long: `Shows the CI checks, approvals, requested changes, mergeability and unresolved
+threads of the pull/merge request for the current branch.
+
+With --watch the status refreshes until all checks have finished, and the
+command fails if any required check failed.`,
+	Args: cobra.NoArgs,
+	RunE: runPRStatus,
+}
+
+func init() {
+	prCmd.AddCommand(prStatusCmd)
+	prStatusCmd.Flags().BoolP("watch", "w", false, "Refresh until all checks have finished")
+	prStatusCmd.Flags().Duration("interval", 10*time.Second, "Refresh interval for --watch")
+}
+
+func runPRStatus(cmd *cobra.Command, args []string) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	repo, err := git.OpenRepository()
+	if err != nil {
+		return err
+	}
+
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	token, err := getTokenForPR(cfg)
+	if err != nil {
+		return err
+	}
+
+	pr, err := findProviderPR(cfg, token, branch)
+	if err != nil {
+		return explainAPIError(cfg.Git.Provider, err)
+	}
+	if pr == nil {
+		return fmt.Errorf("no open pull request found for branch %s", branch)
+	}
+
+	status, err := fetchPRStatus(cfg, token, pr)
+	if err != nil {
+		return explainAPIError(cfg.Git.Provider, err)
+	}
+
+	if watch && !status.ChecksDone() {
+		if interval < time.Second {
+			interval = time.Second
+		}
+
+		s := spinner.New()
+		s.Spinner = spinner.Dot
+		s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+
+		m := &prStatusModel{
+			cfg:      cfg,
+			token:    token,
+			pr:       pr,
+			interval: interval,
+			spinner:  s,
+			status:   status,
+			updated:  time.Now(),
+		}
+
+		finalModel, err := tea.NewProgram(m).Run()
+		if err != nil {
+			return err
+		}
+		status = finalModel.(*prStatusModel).status
+	} else {
+		fmt.Println(renderPRStatus(pr, status))
+	}
+
+	if failed := status.FailedChecks(); watch && len(failed) > 0 {
+		return fmt.Errorf("%d check(s) failed", len(failed))
+	}
+
+	return nil
+}
+
+// fetchPRStatus loads the checks and review state from the provider
+func fetchPRStatus(cfg *config.ProjectConfig, token string, pr *api.PullRequest) (*api.PullRequestStatus, error) {
+	switch cfg.Git.Provider {
+	case "github":
+		client := newGitHubClient(cfg, token)
+		return client.PullRequestStatus(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo, pr.Number)
+	case "gitlab":
+		client := newGitLabClient(cfg, token)
+		return client.MergeRequestStatus(cfg.Git.GitLab.ProjectID, pr.Number)
+	default:
+		return nil, fmt.Errorf("pr status is not supported on %s", cfg.Git.Provider)
+	}
+}
+
+// renderPRStatus formats the status as a checks table and a review table
+func renderPRStatus(pr *api.PullRequest, status *api.PullRequestStatus) string {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("#%d %s", pr.Number, pr.Title)) + "\n")
+	b.WriteString(dimStyle.Render(pr.URL) + "\n\n")
+
+	if len(status.Checks) == 0 {
+		b.WriteString(dimStyle.Render("No checks reported yet") + "\n\n")
+	} else {
+		checks := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderStyle(dimStyle).
+			Headers("CHECK", "STATUS").
+			StyleFunc(func(row, col int) lipgloss.Style {
+				if row == table.HeaderRow {
+					return headerStyle
+				}
+				return cellStyle
+			})
+		for _, check := range status.Checks {
+			name := check.Name
+			if check.Optional {
+				name += dimStyle.Render(" (optional)")
+			}
+			checks.Row(name, checkStateLabel(check.State))
+		}
+		b.WriteString(checks.Render() + "\n\n")
+	}
+
+	approvals := "none"
+	if len(status.Approvals) > 0 {
+		approvals = strings.Join(status.Approvals, ", ")
+	}
+	if status.ApprovalsLeft > 0 {
+		approvals += dimStyle.Render(fmt.Sprintf(" (%d more required)", status.ApprovalsLeft))
+	}
+
+	changes := dimStyle.Render("none")
+	if len(status.ChangesRequested) > 0 {
+		changes = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(strings.Join(status.ChangesRequested, ", "))
+	}
+
+	threads := dimStyle.Render("none")
+	if status.UnresolvedThreads > 0 {
+		threads = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render(fmt.Sprint(status.UnresolvedThreads))
+	}
+
+	review := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(dimStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if col == 0 {
+				return headerStyle
+			}
+			return cellStyle
+		}).
+		Row("Approvals", approvals).
+		Row("Changes requested", changes).
+		Row("Mergeable", mergeStateLabel(status)).
+		Row("Unresolved threads", threads)
+	b.WriteString(review.Render())
+
+	return b.String()
+}
+
+// checkStateLabel renders a check state with a coloured symbol
+func checkStateLabel(state string) string {
+	switch state {
+	case api.CheckSuccess:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ passed")
+	case api.CheckFailure:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("✗ failed")
+	case api.CheckRunning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("● running")
+	case api.CheckPending:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("○ pending")
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("- " + state)
+	}
+}
+
+// mergeStateLabel renders the merge state and the provider's reason
+func mergeStateLabel(status *api.PullRequestStatus) string {
+	switch status.Mergeable {
+	case api.MergeReady:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ ready to merge")
+	case api.MergeConflicts:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("✗ has conflicts")
+	case api.MergeChecking:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("checking...")
+	case api.MergeDraft:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("draft")
+	default:
+		label := status.Mergeable
+		if status.MergeDetail != "" && status.MergeDetail != status.Mergeable {
+			label += " (" + strings.ReplaceAll(status.MergeDetail, "_", " ") + ")"
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render(label)
+	}
+}
+
+// prStatusModel refreshes the PR status until all checks have finished
+type prStatusModel struct {
+	cfg      *config.ProjectConfig
+	token    string
+	pr       *api.PullRequest
+	interval time.Duration
+	spinner  spinner.Model
+	status   *api.PullRequestStatus
+	err      error
+	updated  time.Time
+	done     bool
+}
+
+type prStatusMsg struct {
+	status *api.PullRequestStatus
+	err    error
+}
+
+type prStatusTickMsg struct{}
+
+func (m *prStatusModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.tick())
+}
+
+func (m *prStatusModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return prStatusTickMsg{} })
+}
+
+func (m *prStatusModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		status, err := fetchPRStatus(m.cfg, m.token, m.pr)
+		return prStatusMsg{status: status, err: err}
+	}
+}
+
+func (m *prStatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			m.done = true
+			return m, tea.Quit
+		}
+
+	case prStatusMsg:
+		// Keep the last good status on transient errors
+		m.err = msg.err
+		if msg.err == nil {
+			m.status = msg.status
+			m.updated = time.Now()
+			if m.status.ChecksDone() {
+				m.done = true
+				return m, tea.Quit
+			}
+		}
+		return m, m.tick()
+
+	case prStatusTickMsg:
+		return m, m.fetch()
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *prStatusModel) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	s := renderPRStatus(m.pr, m.status) + "\n"
+	if m.err != nil {
+		s += lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	}
+	if m.done {
+		return s
+	}
+
+	line := fmt.Sprintf("Waiting for checks, updated %s", m.updated.Format("15:04:05"))
+	return s + "\n" + m.spinner.View() + " " + dimStyle.Render(line+" · q to quit") + "\n"
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"one/internal/api"
+	"one/internal/config"
+	"one/internal/git"
+)
+
+// prSubmission is the pull request as it will be sent to the provider
+type prSubmission struct {
+	Title string
+	Body  string
+	Base  string
+	api.PullRequestMetadata
+
+	// SuggestedReviewers are the CODEOWNERS of the changed files
+	SuggestedReviewers []string
+}
+
+// Review step actions
+const (
+	reviewSubmit = "submit"
+	reviewEdit   = "edit"
+	reviewEditor = "editor"
+	reviewMeta   = "meta"
+	reviewCancel = "cancel"
+)
+
+// isInteractive reports whether stdin is a terminal, so prompts can be shown
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// reviewPR shows the rendered pull request and lets the user edit it before
+// it is submitted. Base, reviewers and labels can only be changed for new
+// pull requests. It returns false when the user cancels.
+func reviewPR(cfg *config.ProjectConfig, repo *git.Repository, token string, sub *prSubmission, existing bool) (bool, error) {
+	submitLabel := "Create pull request"
+	if existing {
+		submitLabel = "Update pull request"
+	}
+
+	for {
+		printPRPreview(sub, existing)
+
+		options := []huh.Option[string]{
+			huh.NewOption(submitLabel, reviewSubmit),
+			huh.NewOption("Edit title and body", reviewEdit),
+			huh.NewOption("Open in "+editorCommand(), reviewEditor),
+		}
+		if !existing {
+			options = append(options, huh.NewOption(metadataLabel(cfg), reviewMeta))
+		}
+		options = append(options, huh.NewOption("Cancel", reviewCancel))
+
+		var action string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Review pull request").
+					Options(options...).
+					Value(&action),
+			),
+		)
+		if err := form.Run(); err != nil {
+			return false, err
+		}
+
+		switch action {
+		case reviewSubmit:
+			if strings.TrimSpace(sub.Title) == "" {
+				fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("The title cannot be empty"))
+				continue
+			}
+			return true, nil
+		case reviewEdit:
+			if err := editPRInForm(sub); err != nil {
+				return false, err
+			}
+		case reviewEditor:
+			if err := editPRInEditor(sub); err != nil {
+				return false, err
+			}
+		case reviewMeta:
+			if err := editPRMetadata(cfg, repo, token, sub); err != nil {
+				return false, err
+			}
+		default:
+			return false, nil
+		}
+	}
+}
+
+// printPRPreview prints the title, target and rendered body
+func printPRPreview(sub *prSubmission, existing bool) {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(sub.Title))
+	if !existing {
+		fmt.Println(dimStyle.Render("into " + sub.Base))
+		if len(sub.Reviewers) > 0 {
+			fmt.Println(dimStyle.Render("reviewers: " + strings.Join(sub.Reviewers, ", ")))
+		}
+		if suggested := missingReviewers(sub); len(suggested) > 0 {
+			fmt.Println(dimStyle.Render("suggested reviewers: " + strings.Join(suggested, ", ")))
+		}
+		if len(sub.Assignees) > 0 {
+			fmt.Println(dimStyle.Render("assignees: " + strings.Join(sub.Assignees, ", ")))
+		}
+		if len(sub.Labels) > 0 {
+			fmt.Println(dimStyle.Render("labels: " + strings.Join(sub.Labels, ", ")))
+		}
+		if sub.Milestone != "" {
+			fmt.Println(dimStyle.Render("milestone: " + sub.Milestone))
+		}
+	}
+
+	body := sub.Body
+	if strings.TrimSpace(body) == "" {
+		fmt.Println()
+		fmt.Println(dimStyle.Render("(no description)"))
+		fmt.Println()
+		return
+	}
+
+	r, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(100),
+	)
+	if err == nil {
+		if rendered, err := r.Render(body); err == nil {
+			body = rendered
+		}
+	}
+	fmt.Println(body)
+}
+
+// editPRInForm edits the title and body in a form
+func editPRInForm(sub *prSubmission) error {
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Title").
+				Value(&sub.Title),
+			huh.NewText().
+				Title("Body").
+				Description("Markdown").
+				Lines(15).
+				Value(&sub.Body),
+		),
+	)
+	return form.Run()
+}
+
+// editPRInEditor opens the title and body in $VISUAL or $EDITOR. The first
+// line is the title and the rest, after a blank line, is the body.
+func editPRInEditor(sub *prSubmission) error {
+	file, err := os.CreateTemp("", "one-pr-*.md")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(sub.Title + "\n\n" + sub.Body + "\n"); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	file.Close()
+
+	// The editor may come with arguments, e.g. "code --wait"
+	cmd := exec.Command("sh", "-c", editorCommand()+` "$1"`, "sh", file.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor: %w", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read temp file: %w", err)
+	}
+
+	title, body, _ := strings.Cut(strings.TrimLeft(string(data), "\n"), "\n")
+	sub.Title = strings.TrimSpace(title)
+	sub.Body = strings.TrimSpace(body)
+	return nil
+}
+
+// editorCommand returns the user's editor, falling back to vi
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// missingReviewers returns the suggested reviewers that are not requested yet
+func missingReviewers(sub *prSubmission) []string {
+	var missing []string
+	for _, reviewer := range sub.SuggestedReviewers {
+		if !containsFold(sub.Reviewers, reviewer) {
+			missing = append(missing, reviewer)
+		}
+	}
+	return missing
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsPRMetadata reports whether one can set reviewers, assignees,
+// labels and milestones on the provider
+func supportsPRMetadata(cfg *config.ProjectConfig) bool {
+	return cfg.Git.Provider == "github" || cfg.Git.Provider == "gitlab"
+}
+
+// metadataLabel names what the metadata step can change on this provider
+func metadataLabel(cfg *config.ProjectConfig) string {
+	if supportsPRMetadata(cfg) {
+		return "Change base, reviewers and labels"
+	}
+	return "Change base branch"
+}
+
+// editPRMetadata lets the user pick the base branch and, where the provider
+// supports it, reviewers, assignees, labels and the milestone
+func editPRMetadata(cfg *config.ProjectConfig, repo *git.Repository, token string, sub *prSubmission) error {
+	branches, _ := repo.RemoteBranches(cfg.Git.Remote)
+
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Base branch").
+			Suggestions(branches).
+			Value(&sub.Base).
+			Validate(func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return fmt.Errorf("base branch is required")
+				}
+				return nil
+			}),
+	}
+
+	if !supportsPRMetadata(cfg) {
+		if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+			return err
+		}
+		sub.Base = strings.TrimSpace(sub.Base)
+		return nil
+	}
+
+	reviewersHelp := "Comma-separated usernames"
+	if cfg.Git.Provider == "github" {
+		reviewersHelp += " or org/team"
+	}
+	if len(sub.SuggestedReviewers) > 0 {
+		reviewersHelp += "; CODEOWNERS: " + strings.Join(sub.SuggestedReviewers, ", ")
+	}
+
+	reviewers := strings.Join(sub.Reviewers, ", ")
+	assignees := strings.Join(sub.Assignees, ", ")
+	fields = append(fields,
+		huh.NewInput().
+			Title("Reviewers").
+			Description(reviewersHelp).
+			Suggestions(sub.SuggestedReviewers).
+			Value(&reviewers),
+		huh.NewInput().
+			Title("Assignees").
+			Description("Comma-separated usernames").
+			Value(&assignees),
+	)
+
+	labels := strings.Join(sub.Labels, ", ")
+	selected := sub.Labels
+	available, _ := listProviderLabels(cfg, token)
+	if len(available) > 0 {
+		options := make([]huh.Option[string], 0, len(available))
+		for _, label := range available {
+			options = append(options, huh.NewOption(label, label))
+		}
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("Labels").
+			Options(options...).
+			Filterable(true).
+			Value(&selected))
+	} else {
+		fields = append(fields, huh.NewInput().
+			Title("Labels").
+			Description("Comma-separated").
+			Value(&labels))
+	}
+
+	fields = append(fields, huh.NewInput().
+		Title("Milestone").
+		Description("Milestone title, leave empty for none").
+		Value(&sub.Milestone))
+
+	if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return err
+	}
+
+	sub.Base = strings.TrimSpace(sub.Base)
+	sub.Milestone = strings.TrimSpace(sub.Milestone)
+	sub.Reviewers = splitList(reviewers)
+	sub.Assignees = splitList(assignees)
+	if len(available) > 0 {
+		sub.Labels = selected
+	} else {
+		sub.Labels = splitList(labels)
+	}
+	return nil
+}
+
+// listProviderLabels returns the labels defined on the repository, if the
+// provider supports labels
+func listProviderLabels(cfg *config.ProjectConfig, token string) ([]string, error) {
+	switch cfg.Git.Provider {
+	case "github":
+		if cfg.Git.GitHub == nil {
+			return nil, fmt.Errorf("GitHub configuration missing")
+		}
+		return newGitHubClient(cfg, token).ListLabels(cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo)
+	case "gitlab":
+		if cfg.Git.GitLab == nil {
+			return nil, fmt.Errorf("GitLab configuration missing")
+		}
+		return newGitLabClient(cfg, token).ListLabels(cfg.Git.GitLab.ProjectID)
+	default:
+		return nil, nil
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
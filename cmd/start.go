@@ -2,25 +2,24 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
-	"one/internal/api"
-	"one/internal/auth"
 	"one/internal/config"
 	"one/internal/git"
 )
 
 var startCmd = &cobra.Command{
-	Use:   "start <TICKET-ID>",
+	Use:   "start [TICKET-ID]",
 	Short: "Start working on a new task",
-	Long:  `Creates a new branch and optionally fetches ticket information.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runStart,
+	Long: `Creates a new branch and optionally fetches ticket information.
+
+Without a ticket ID, pick one of the tickets assigned to you (see 'one tickets').`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStart,
 }
 
 func init() {
@@ -45,7 +44,6 @@ type ticketFetchedMsg struct {
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
-	ticketID := args[0]
 	description, _ := cmd.Flags().GetString("description")
 
 	// Load config
@@ -54,6 +52,23 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if len(args) == 0 {
+		if cfg.Ticket == nil || !isInteractive() {
+			return fmt.Errorf("a ticket ID is required")
+		}
+		ticketID, err := pickTicket(cfg, false)
+		if err != nil {
+			return err
+		}
+		return startTask(cfg, ticketID, description)
+	}
+
+	return startTask(cfg, normalizeTicketID(cfg, args[0]), description)
+}
+
+// startTask checks out the base branch, pulls it and creates the task
+// branch for a ticket, stashing uncommitted changes on the way
+func startTask(cfg *config.ProjectConfig, ticketID, description string) error {
 	// Open repository
 	repo, err := git.OpenRepository()
 	if err != nil {
@@ -66,34 +81,8 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to check git status: %w", err)
 	}
 
-	// If not clean, ask user what to do
-	if !clean {
-		var shouldStash bool
-		form := huh.NewForm(
-			huh.NewGroup(
-				huh.NewConfirm().
-					Title("Working directory has uncommitted changes").
-					Description("Would you like to stash them?").
-					Affirmative("Yes, stash changes").
-					Negative("No, cancel").
-					Value(&shouldStash),
-			),
-		)
-
-		if err := form.Run(); err != nil {
-			return err
-		}
-
-		if !shouldStash {
-			return fmt.Errorf("cannot start new task with uncommitted changes")
-		}
-
-		// TODO: Implement stash functionality
-		fmt.Println("⚠️  Stash functionality not yet implemented. Please commit or stash manually.")
-		return fmt.Errorf("working directory not clean")
-	}
-
-	// If no description and no ticket system configured, prompt for it
+	// If no description and no ticket system configured, prompt for it.
+	// This comes before stashing so cancelling leaves the worktree alone.
 	if description == "" && cfg.Ticket == nil {
 		form := huh.NewForm(
 			huh.NewGroup(
@@ -110,6 +99,25 @@ func runStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// If not clean, stash the changes before switching branches
+	var stashMode, stashMessage string
+	if !clean {
+		stashMode, stashMessage, err = stashForStart(cfg, repo, ticketID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Every return from here on says where the stashed changes went
+	restored := false
+	restore := func(started bool) {
+		if stashMessage != "" && !restored {
+			restored = true
+			restoreStartStash(repo, stashMode, stashMessage, started)
+		}
+	}
+	defer restore(false)
+
 	m := &startModel{
 		ticketID:    ticketID,
 		description: description,
@@ -125,13 +133,98 @@ func runStart(cmd *cobra.Command, args []string) error {
 	}
 
 	fm := finalModel.(*startModel)
+	restore(fm.err == nil && fm.branchName != "")
 	if fm.err != nil {
 		return fm.err
 	}
 
+	runJiraStep(cfg, jiraStepStart, repo, fm.branchName, ticketID, nil)
+
 	return nil
 }
 
+const (
+	stashCarry = "carry"
+	stashPark  = "park"
+)
+
+// stashForStart asks what to do with uncommitted changes and stashes them.
+// Carried changes are labelled with the new ticket, parked ones with the
+// ticket of the branch they were made on.
+func stashForStart(cfg *config.ProjectConfig, repo *git.Repository, ticketID string) (string, string, error) {
+	var mode string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Working directory has uncommitted changes").
+				Description("They will be stashed before switching branches").
+				Options(
+					huh.NewOption("Bring them to the new branch", stashCarry),
+					huh.NewOption("Leave them parked in the stash", stashPark),
+					huh.NewOption("Cancel", ""),
+				).
+				Value(&mode),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return "", "", err
+	}
+
+	if mode == "" {
+		return "", "", fmt.Errorf("cannot start new task with uncommitted changes")
+	}
+
+	currentBranch, err := repo.CurrentBranch()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	label := ticketID
+	if mode == stashPark {
+		label = currentBranch
+		if cfg.BranchPatterns != nil && cfg.BranchPatterns.TicketID != "" {
+			if id, err := git.ParseTicketID(currentBranch, cfg.BranchPatterns.TicketID); err == nil {
+				label = id
+			}
+		}
+	}
+
+	message := git.StashLabel(label, currentBranch)
+	if err := repo.Stash(message); err != nil {
+		return "", "", err
+	}
+
+	return mode, message, nil
+}
+
+// restoreStartStash pops carried changes onto the new branch, or tells the
+// user where their changes are parked
+func restoreStartStash(repo *git.Repository, mode, message string, started bool) {
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+
+	entry, err := repo.FindStash(message)
+	if err != nil {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("ℹ Your changes were stashed as %q", message)))
+		return
+	}
+
+	if mode == stashCarry && started {
+		if err := repo.PopStash(entry.Ref); err != nil {
+			errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+			fmt.Println(errorStyle.Render(fmt.Sprintf("✗ Could not restore your changes: %v", err)))
+			fmt.Printf("  They are still in %s; resolve and run 'git stash pop %s'\n", entry.Ref, entry.Ref)
+			return
+		}
+		fmt.Println(successStyle.Render("✓ Restored your stashed changes"))
+		return
+	}
+
+	fmt.Println(infoStyle.Render(fmt.Sprintf("ℹ Your changes are parked in %s (%s)", entry.Ref, message)))
+	fmt.Println("  Run 'one stash' to list them")
+}
+
 func (m *startModel) Init() tea.Cmd {
 	return m.startTask()
 }
@@ -169,32 +262,11 @@ func (m *startModel) startTask() tea.Cmd {
 }
 
 func (m *startModel) fetchTicketTitle() (string, error) {
-	if m.cfg.Ticket == nil {
-		return "", fmt.Errorf("no ticket system configured")
-	}
-
-	// Try to get token
-	token, err := auth.GetToken(m.cfg.Ticket.System, m.cfg.Project.Name)
+	details, err := fetchTicketDetails(m.cfg, m.ticketID)
 	if err != nil {
-		// Try environment variable
-		if m.cfg.Ticket.Jira != nil && m.cfg.Ticket.Jira.TokenEnv != "" {
-			if envToken := os.Getenv(m.cfg.Ticket.Jira.TokenEnv); envToken != "" {
-				token = &auth.Token{AccessToken: envToken}
-			}
-		}
-	}
-
-	if token == nil {
-		return "", fmt.Errorf("not authenticated with %s", m.cfg.Ticket.System)
-	}
-
-	switch m.cfg.Ticket.System {
-	case "jira":
-		client := api.NewJiraClient(m.cfg.Ticket.BaseURL, token.AccessToken)
-		return client.GetIssue(m.ticketID)
-	default:
-		return "", fmt.Errorf("ticket system %s not supported for fetching", m.cfg.Ticket.System)
+		return "", err
 	}
+	return details.Title, nil
 }
 
 func (m *startModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
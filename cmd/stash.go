@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"one/internal/git"
+)
+
+var stashCmd = &cobra.Command{
+	Use:   "stash",
+	Short: "List changes stashed by one",
+	Long:  `Lists the stashes created by 'one start', with the ticket and branch each one belongs to.`,
+	Args:  cobra.NoArgs,
+	RunE:  runStash,
+}
+
+func init() {
+	rootCmd.AddCommand(stashCmd)
+}
+
+func runStash(cmd *cobra.Command, args []string) error {
+	repo, err := git.OpenRepository()
+	if err != nil {
+		return err
+	}
+
+	entries, err := repo.ListStashes()
+	if err != nil {
+		return err
+	}
+
+	var stashes []git.StashEntry
+	for _, entry := range entries {
+		if entry.TicketID != "" {
+			stashes = append(stashes, entry)
+		}
+	}
+
+	if len(stashes) == 0 {
+		fmt.Println("No stashes created by one.")
+		return nil
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString("# Stashed Changes\n\n")
+	markdown.WriteString("| Stash | Ticket | From branch | Created |\n")
+	markdown.WriteString("|-------|--------|-------------|---------|\n")
+	for _, entry := range stashes {
+		markdown.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n",
+			entry.Ref, entry.TicketID, entry.Branch, relativeAge(entry.Created)))
+	}
+	markdown.WriteString("\nRestore one with `git stash pop <stash>`.\n")
+
+	rendered, err := renderMarkdown(markdown.String())
+	if err != nil {
+		// Fallback to plain text
+		fmt.Println("Stashed Changes:")
+		fmt.Println()
+		for _, entry := range stashes {
+			fmt.Printf("  ● %s  %s  (from %s, %s)\n", entry.Ref, entry.TicketID, entry.Branch, relativeAge(entry.Created))
+		}
+		fmt.Println("\nRestore one with 'git stash pop <stash>'.")
+		return nil
+	}
+
+	fmt.Println(rendered)
+	return nil
+}
+
+// relativeAge formats how long ago something happened
+func relativeAge(created time.Time) string {
+	if created.IsZero() {
+		return "unknown"
+	}
+
+	age := time.Since(created)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
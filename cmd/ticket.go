@@ -2,27 +2,38 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"one/internal/api"
 	"one/internal/browser"
 	"one/internal/config"
+	"one/internal/git"
 	"one/internal/template"
 )
 
 var ticketCmd = &cobra.Command{
-	Use:   "ticket <TICKET-ID>",
+	Use:   "ticket [TICKET-ID]",
 	Short: "Open a ticket in the browser",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTicket,
+	Long: `Opens a ticket in the browser. With --show, or when no browser is
+configured, the ticket is shown in the terminal instead.
+
+Without a ticket ID, the ticket of the current branch is used. It is read
+with branch_patterns.ticket_id, or guessed from common branch names such as
+ABC-123-fix-login, feature/ENG-42 or 123-fix-login when no pattern is set.
+Branch type words such as hotfix-123 or release-1.2 are not ticket keys.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTicket,
 }
 
 func init() {
 	rootCmd.AddCommand(ticketCmd)
+	ticketCmd.Flags().Bool("show", false, "Show the ticket in the terminal")
 }
 
 func runTicket(cmd *cobra.Command, args []string) error {
-	ticketID := args[0]
+	show, _ := cmd.Flags().GetBool("show")
 
 	// Load config
 	cfg, err := config.LoadProjectConfig()
@@ -34,6 +45,17 @@ func runTicket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no ticket system configured for this project")
 	}
 
+	var ticketID string
+	if len(args) > 0 {
+		ticketID = args[0]
+	} else if ticketID, err = currentTicketID(cfg); err != nil {
+		return err
+	}
+
+	if show || cfg.Browser.Type == "" {
+		return showTicket(cfg, normalizeTicketID(cfg, ticketID))
+	}
+
 	// Generate ticket URL
 	url := template.BuildTicketURL(cfg.Ticket.System, cfg.Ticket.BaseURL, ticketID)
 
@@ -47,3 +69,172 @@ func runTicket(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// currentTicketID reads the ticket ID from the current branch
+func currentTicketID(cfg *config.ProjectConfig) (string, error) {
+	repo, err := git.OpenRepository()
+	if err != nil {
+		return "", err
+	}
+
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	var ticketID string
+	if cfg.BranchPatterns != nil && cfg.BranchPatterns.TicketID != "" {
+		ticketID, err = git.ParseTicketID(branch, cfg.BranchPatterns.TicketID)
+	} else {
+		ticketID, err = git.GuessTicketID(branch, cfg.Ticket.System)
+	}
+	if err != nil {
+		return "", fmt.Errorf("no ticket ID found in branch %s, pass one as an argument", branch)
+	}
+
+	return ticketID, nil
+}
+
+// ticketDetails holds the ticket fields fetched from the ticket system
+type ticketDetails struct {
+	ID       string
+	Title    string
+	Type     string
+	Priority string
+	State    string
+	Assignee string
+	Labels   []string
+	URL      string
+}
+
+// fetchTicketDetails fetches a ticket from the configured ticket system
+func fetchTicketDetails(cfg *config.ProjectConfig, ticketID string) (*ticketDetails, error) {
+	if cfg.Ticket == nil {
+		return nil, fmt.Errorf("no ticket system configured")
+	}
+
+	token, err := getTicketToken(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Ticket.System {
+	case "jira":
+		client := newJiraClient(cfg, token)
+		issue, err := client.GetIssue(ticketID)
+		if err != nil {
+			return nil, err
+		}
+		return &ticketDetails{
+			ID:       issue.Key,
+			Title:    issue.Summary,
+			Type:     issue.Type,
+			Priority: issue.Priority,
+			State:    issue.Status,
+			Assignee: issue.Assignee,
+			Labels:   issue.Labels,
+		}, nil
+	case "linear":
+		client := api.NewLinearClient(token)
+		issue, err := client.GetIssue(ticketID)
+		if err != nil {
+			return nil, err
+		}
+		return &ticketDetails{
+			ID:       issue.Identifier,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			State:    issue.State,
+			Assignee: issue.Assignee,
+			Labels:   issue.Labels,
+			URL:      issue.URL,
+		}, nil
+	case "github":
+		owner, repo, err := githubIssuesRepo(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client := newGitHubClient(cfg, token)
+		issue, err := client.GetIssue(owner, repo, normalizeTicketID(cfg, ticketID))
+		if err != nil {
+			return nil, err
+		}
+		return &ticketDetails{
+			ID:       fmt.Sprint(issue.Number),
+			Title:    issue.Title,
+			Type:     issue.Type,
+			State:    issue.State,
+			Assignee: issue.Assignee,
+			Labels:   issue.Labels,
+			URL:      issue.HTMLURL,
+		}, nil
+	default:
+		return nil, fmt.Errorf("ticket system %s not supported for fetching", cfg.Ticket.System)
+	}
+}
+
+// githubIssuesRepo returns the repository that holds GitHub issues, falling
+// back to the git.github repository
+func githubIssuesRepo(cfg *config.ProjectConfig) (string, string, error) {
+	var owner, repo string
+	if cfg.Git.GitHub != nil {
+		owner, repo = cfg.Git.GitHub.Owner, cfg.Git.GitHub.Repo
+	}
+	if gh := cfg.Ticket.GitHub; gh != nil {
+		if gh.Owner != "" {
+			owner = gh.Owner
+		}
+		if gh.Repo != "" {
+			repo = gh.Repo
+		}
+	}
+
+	if owner == "" || repo == "" {
+		return "", "", fmt.Errorf("GitHub Issues repository not configured (set ticket.github.owner and ticket.github.repo)")
+	}
+
+	return owner, repo, nil
+}
+
+// normalizeTicketID strips decorations users commonly type around ticket
+// IDs, such as the leading "#" of a GitHub issue number
+func normalizeTicketID(cfg *config.ProjectConfig, ticketID string) string {
+	if cfg.Ticket != nil && cfg.Ticket.System == "github" {
+		return strings.TrimPrefix(ticketID, "#")
+	}
+	return ticketID
+}
+
+// closingKeyword returns the GitHub closing keyword for an issue, qualified
+// with the issue repository when it differs from the PR repository
+func closingKeyword(cfg *config.ProjectConfig, ticketID string) string {
+	if cfg.Ticket == nil || cfg.Ticket.System != "github" || cfg.Git.Provider != "github" {
+		return ""
+	}
+
+	owner, repo, err := githubIssuesRepo(cfg)
+	if err != nil {
+		return ""
+	}
+
+	ref := "#" + normalizeTicketID(cfg, ticketID)
+	if cfg.Git.GitHub != nil && (cfg.Git.GitHub.Owner != owner || cfg.Git.GitHub.Repo != repo) {
+		ref = owner + "/" + repo + ref
+	}
+
+	return "Closes " + ref
+}
+
+// getTicketToken resolves the ticket system token from the keyring or the
+// configured environment variable
+func getTicketToken(cfg *config.ProjectConfig) (string, error) {
+	if cfg.Ticket.System == "jira" && jiraAuthMode(cfg) == api.JiraAuthOAuth {
+		return jiraOAuthToken(cfg)
+	}
+
+	token := resolveToken(cfg, cfg.Ticket.System, ticketTokenEnv(cfg))
+	if token.Source == "" {
+		return "", fmt.Errorf("not authenticated with %s", cfg.Ticket.System)
+	}
+	return token.Value, nil
+}
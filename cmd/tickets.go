@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/config"
+	"one/internal/template"
+)
+
+var ticketsCmd = &cobra.Command{
+	Use:   "tickets",
+	Short: "List your assigned tickets and start one",
+	Long: `Lists the open tickets assigned to you in the project's ticket system and
+lets you pick one to start working on, like 'one start <TICKET-ID>'.
+
+Jira tickets are limited to the board's active sprint, falling back to all
+open tickets when there is no sprint. Use --all to skip the sprint filter.
+Without a terminal the tickets are only listed.`,
+	Args: cobra.NoArgs,
+	RunE: runTickets,
+}
+
+func init() {
+	rootCmd.AddCommand(ticketsCmd)
+	ticketsCmd.Flags().Bool("all", false, "Include Jira tickets outside the active sprint")
+	ticketsCmd.Flags().StringP("description", "d", "", "Custom branch description")
+}
+
+// maxAssignedTickets caps how many tickets are fetched
+const maxAssignedTickets = 100
+
+func runTickets(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	description, _ := cmd.Flags().GetString("description")
+
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Ticket == nil {
+		return fmt.Errorf("no ticket system configured for this project")
+	}
+
+	if !isInteractive() {
+		tickets, err := assignedTickets(cfg, all)
+		if err != nil {
+			return explainAPIError(cfg.Ticket.System, err)
+		}
+		printTickets(tickets)
+		return nil
+	}
+
+	ticketID, err := pickTicket(cfg, all)
+	if err != nil {
+		return err
+	}
+
+	return startTask(cfg, ticketID, description)
+}
+
+// pickTicket lets the user choose one of their assigned tickets
+func pickTicket(cfg *config.ProjectConfig, all bool) (string, error) {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	fmt.Println(dimStyle.Render(fmt.Sprintf("Fetching your tickets from %s...", cfg.Ticket.System)))
+
+	tickets, err := assignedTickets(cfg, all)
+	if err != nil {
+		return "", explainAPIError(cfg.Ticket.System, err)
+	}
+	if len(tickets) == 0 {
+		return "", fmt.Errorf("no open tickets are assigned to you")
+	}
+
+	width := 0
+	for _, t := range tickets {
+		width = max(width, len(t.ID))
+	}
+
+	options := make([]huh.Option[string], 0, len(tickets))
+	for _, t := range tickets {
+		label := fmt.Sprintf("%-*s  %s", width, t.ID, truncateText(t.Title, 60))
+		if t.State != "" {
+			label += "  (" + t.State + ")"
+		}
+		options = append(options, huh.NewOption(label, t.ID))
+	}
+
+	var ticketID string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Start working on").
+				Description("Type to filter").
+				Options(options...).
+				Filtering(true).
+				Height(min(len(options)+2, 15)).
+				Value(&ticketID),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+
+	return ticketID, nil
+}
+
+// assignedTickets fetches the open tickets assigned to the user
+func assignedTickets(cfg *config.ProjectConfig, all bool) ([]ticketDetails, error) {
+	token, err := getTicketToken(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []ticketDetails
+	switch cfg.Ticket.System {
+	case "jira":
+		client := newJiraClient(cfg, token)
+		issues, err := client.SearchIssues(jiraAssignedJQL(cfg, !all), maxAssignedTickets)
+		// Boards without sprints reject or come back empty for openSprints()
+		if !all && (err != nil || len(issues) == 0) {
+			issues, err = client.SearchIssues(jiraAssignedJQL(cfg, false), maxAssignedTickets)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			tickets = append(tickets, ticketDetails{
+				ID:       issue.Key,
+				Title:    issue.Summary,
+				Type:     issue.Type,
+				Priority: issue.Priority,
+				State:    issue.Status,
+				Assignee: issue.Assignee,
+				Labels:   issue.Labels,
+				URL:      template.BuildTicketURL(cfg.Ticket.System, cfg.Ticket.BaseURL, issue.Key),
+			})
+		}
+	case "linear":
+		issues, err := api.NewLinearClient(token).AssignedIssues()
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			tickets = append(tickets, ticketDetails{
+				ID:       issue.Identifier,
+				Title:    issue.Title,
+				Priority: issue.Priority,
+				State:    issue.State,
+				Assignee: issue.Assignee,
+				Labels:   issue.Labels,
+				URL:      issue.URL,
+			})
+		}
+	case "github":
+		owner, repo, err := githubIssuesRepo(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client := newGitHubClient(cfg, token)
+		user, err := client.CurrentUser()
+		if err != nil {
+			return nil, err
+		}
+		issues, err := client.ListAssignedIssues(owner, repo, user.Login)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			tickets = append(tickets, ticketDetails{
+				ID:       fmt.Sprint(issue.Number),
+				Title:    issue.Title,
+				Type:     issue.Type,
+				State:    issue.State,
+				Assignee: issue.Assignee,
+				Labels:   issue.Labels,
+				URL:      issue.HTMLURL,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("ticket system %s not supported for listing", cfg.Ticket.System)
+	}
+
+	return tickets, nil
+}
+
+// jiraAssignedJQL builds the query for the user's open tickets on the
+// board, optionally limited to the active sprint
+func jiraAssignedJQL(cfg *config.ProjectConfig, sprint bool) string {
+	jql := "assignee = currentUser() AND statusCategory != Done"
+	if cfg.Ticket.Jira != nil && cfg.Ticket.Jira.BoardID != "" {
+		jql += fmt.Sprintf(" AND project = %q", cfg.Ticket.Jira.BoardID)
+	}
+	if sprint {
+		jql += " AND sprint in openSprints()"
+	}
+	return jql + " ORDER BY priority DESC, updated DESC"
+}
+
+func printTickets(tickets []ticketDetails) {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	if len(tickets) == 0 {
+		fmt.Println(dimStyle.Render("No open tickets are assigned to you"))
+		return
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(dimStyle).
+		Headers("TICKET", "TITLE", "STATUS", "PRIORITY").
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return cellStyle
+		})
+	for _, ticket := range tickets {
+		t.Row(ticket.ID, truncateText(ticket.Title, 60), ticket.State, ticket.Priority)
+	}
+	fmt.Println(t.Render())
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+
+	"one/internal/api"
+	"one/internal/config"
+	"one/internal/template"
+)
+
+// ticketComments is how many of the latest comments are shown
+const ticketComments = 5
+
+// showTicket renders a ticket with its description, sub-tasks, linked pull
+// requests and latest comments in the terminal
+func showTicket(cfg *config.ProjectConfig, ticketID string) error {
+	details, err := fetchTicketDetails(cfg, ticketID)
+	if err != nil {
+		return explainAPIError(cfg.Ticket.System, err)
+	}
+
+	view, err := fetchTicketView(cfg, ticketID)
+	if err != nil {
+		return explainAPIError(cfg.Ticket.System, err)
+	}
+
+	if details.URL == "" {
+		details.URL = template.BuildTicketURL(cfg.Ticket.System, cfg.Ticket.BaseURL, ticketID)
+	}
+
+	doc := ticketMarkdown(details, view)
+	r, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(100),
+	)
+	if err == nil {
+		if rendered, err := r.Render(doc); err == nil {
+			doc = rendered
+		}
+	}
+	fmt.Println(doc)
+
+	return nil
+}
+
+// fetchTicketView fetches the content of a ticket from the configured
+// ticket system
+func fetchTicketView(cfg *config.ProjectConfig, ticketID string) (*api.IssueView, error) {
+	token, err := getTicketToken(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Ticket.System {
+	case "jira":
+		return newJiraClient(cfg, token).IssueView(ticketID, ticketComments)
+	case "linear":
+		return api.NewLinearClient(token).IssueView(ticketID, ticketComments)
+	case "github":
+		owner, repo, err := githubIssuesRepo(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return newGitHubClient(cfg, token).IssueView(owner, repo, ticketID, ticketComments)
+	default:
+		return nil, fmt.Errorf("ticket system %s not supported for fetching", cfg.Ticket.System)
+	}
+}
+
+// ticketMarkdown lays out a ticket as a Markdown document
+func ticketMarkdown(details *ticketDetails, view *api.IssueView) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s · %s\n\n", details.ID, details.Title)
+
+	assignee := details.Assignee
+	if assignee == "" {
+		assignee = "Unassigned"
+	}
+	fields := []string{"**Status** " + details.State, "**Assignee** " + assignee}
+	if details.Priority != "" {
+		fields = append(fields, "**Priority** "+details.Priority)
+	}
+	if details.Type != "" {
+		fields = append(fields, "**Type** "+details.Type)
+	}
+	b.WriteString(strings.Join(fields, " · ") + "\n")
+	if len(details.Labels) > 0 {
+		b.WriteString("\n**Labels** " + strings.Join(details.Labels, ", ") + "\n")
+	}
+	if details.URL != "" {
+		b.WriteString("\n<" + details.URL + ">\n")
+	}
+
+	b.WriteString("\n## Description\n\n")
+	if description := strings.TrimSpace(view.Description); description != "" {
+		b.WriteString(description + "\n")
+	} else {
+		b.WriteString("_No description_\n")
+	}
+
+	if len(view.Subtasks) > 0 {
+		b.WriteString("\n## Sub-tasks\n\n")
+		for _, task := range view.Subtasks {
+			b.WriteString("- " + issueRefMarkdown(task) + "\n")
+		}
+	}
+
+	if len(view.PullRequests) > 0 {
+		b.WriteString("\n## Pull Requests\n\n")
+		for _, pr := range view.PullRequests {
+			b.WriteString("- " + issueRefMarkdown(pr) + "\n")
+		}
+	}
+
+	if len(view.Comments) > 0 {
+		b.WriteString("\n## Latest Comments\n")
+		for _, comment := range view.Comments {
+			author := comment.Author
+			if author == "" {
+				author = "Unknown"
+			}
+			fmt.Fprintf(&b, "\n### %s · %s\n\n%s\n", author, relativeAge(comment.Created), strings.TrimSpace(comment.Body))
+		}
+	}
+
+	return b.String()
+}
+
+// issueRefMarkdown formats a sub-task or pull request as a list entry
+func issueRefMarkdown(ref api.IssueRef) string {
+	text := strings.TrimSpace(ref.ID + " " + ref.Title)
+	if ref.URL != "" {
+		text = "[" + text + "](" + ref.URL + ")"
+	}
+	if ref.State != "" {
+		text += " _(" + ref.State + ")_"
+	}
+	return text
+}
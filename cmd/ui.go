@@ -0,0 +1,809 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"one/internal/api"
+	"one/internal/browser"
+	"one/internal/config"
+	"one/internal/git"
+	"one/internal/hooks"
+	"one/internal/template"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Open the interactive dashboard",
+	Long: `Opens a full-screen dashboard for the current project: the branch you are on,
+its ticket and pull request, and your recent branches. From there you can
+start a task, create or open the PR, open the ticket, check out a recent
+branch or switch to another project.`,
+	Args: cobra.NoArgs,
+	RunE: runUI,
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
+
+func runUI(cmd *cobra.Command, args []string) error {
+	if !isInteractive() {
+		return fmt.Errorf("one ui needs an interactive terminal")
+	}
+
+	_, err := tea.NewProgram(newUIModel(), tea.WithAltScreen()).Run()
+	return err
+}
+
+// Dashboard views
+const (
+	uiViewDashboard = iota
+	uiViewStart
+	uiViewProjects
+)
+
+// recentBranchLimit is how many branches the dashboard lists
+const recentBranchLimit = 10
+
+// uiModel is the one ui dashboard. Local git state loads first; the ticket
+// and the PR are fetched in the background once the branch is known.
+type uiModel struct {
+	view    int
+	width   int
+	spinner spinner.Model
+	input   textinput.Model
+
+	cfg    *config.ProjectConfig
+	cfgErr error
+
+	// gen is bumped on every reload so that results for a previous
+	// project or branch are dropped
+	gen int
+
+	local         *uiLocalState
+	localErr      error
+	ticket        *ticketDetails
+	ticketErr     error
+	ticketLoading bool
+	pr            *api.PullRequest
+	prStatus      *api.PullRequestStatus
+	prErr         error
+	prLoading     bool
+
+	cursor        int
+	projects      []*config.ProjectConfig
+	projectCursor int
+
+	message    string
+	messageErr bool
+}
+
+// uiLocalState is what the dashboard reads from the repository
+type uiLocalState struct {
+	branch   string
+	clean    bool
+	ticketID string
+	branches []git.BranchInfo
+}
+
+type uiLocalMsg struct {
+	gen   int
+	state *uiLocalState
+	err   error
+}
+
+type uiTicketMsg struct {
+	gen    int
+	ticket *ticketDetails
+	err    error
+}
+
+type uiPRMsg struct {
+	gen    int
+	pr     *api.PullRequest
+	status *api.PullRequestStatus
+	err    error
+}
+
+// uiNoticeMsg reports the outcome of an action in the footer
+type uiNoticeMsg struct {
+	text   string
+	err    error
+	reload bool
+}
+
+func newUIModel() *uiModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+
+	input := textinput.New()
+	input.Prompt = "Ticket ID: "
+	input.Placeholder = "PROJ-123"
+
+	return &uiModel{spinner: s, input: input}
+}
+
+func (m *uiModel) Init() tea.Cmd {
+	return m.reload()
+}
+
+// reload reads the project config for the current directory and starts
+// loading the dashboard
+func (m *uiModel) reload() tea.Cmd {
+	m.gen++
+	m.local, m.localErr = nil, nil
+	m.ticket, m.ticketErr, m.ticketLoading = nil, nil, false
+	m.pr, m.prStatus, m.prErr, m.prLoading = nil, nil, nil, false
+
+	m.cfg, m.cfgErr = config.LoadProjectConfig()
+	if m.cfgErr != nil {
+		return nil
+	}
+
+	return tea.Batch(m.spinner.Tick, loadUILocal(m.gen, m.cfg))
+}
+
+func loadUILocal(gen int, cfg *config.ProjectConfig) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := git.OpenRepository()
+		if err != nil {
+			return uiLocalMsg{gen: gen, err: err}
+		}
+
+		branch, err := repo.CurrentBranch()
+		if err != nil {
+			return uiLocalMsg{gen: gen, err: err}
+		}
+
+		clean, err := repo.IsClean()
+		if err != nil {
+			return uiLocalMsg{gen: gen, err: fmt.Errorf("failed to check git status: %w", err)}
+		}
+
+		state := &uiLocalState{branch: branch, clean: clean}
+		if cfg.BranchPatterns != nil && cfg.BranchPatterns.TicketID != "" {
+			if id, err := git.ParseTicketID(branch, cfg.BranchPatterns.TicketID); err == nil {
+				state.ticketID = id
+			}
+		}
+
+		state.branches, err = repo.RecentBranches(recentBranchLimit)
+		if err != nil {
+			return uiLocalMsg{gen: gen, err: err}
+		}
+
+		return uiLocalMsg{gen: gen, state: state}
+	}
+}
+
+func loadUITicket(gen int, cfg *config.ProjectConfig, ticketID string) tea.Cmd {
+	return func() tea.Msg {
+		ticket, err := fetchTicketDetails(cfg, ticketID)
+		if err != nil && cfg.Ticket != nil {
+			err = explainAPIError(cfg.Ticket.System, err)
+		}
+		return uiTicketMsg{gen: gen, ticket: ticket, err: err}
+	}
+}
+
+func loadUIPR(gen int, cfg *config.ProjectConfig, branch string) tea.Cmd {
+	return func() tea.Msg {
+		token, err := getTokenForPR(cfg)
+		if err != nil {
+			return uiPRMsg{gen: gen, err: err}
+		}
+
+		pr, err := findProviderPR(cfg, token, branch)
+		if err != nil {
+			return uiPRMsg{gen: gen, err: explainAPIError(cfg.Git.Provider, err)}
+		}
+		if pr == nil {
+			return uiPRMsg{gen: gen}
+		}
+
+		// Not every provider reports checks, so the PR is shown without them
+		status, _ := fetchPRStatus(cfg, token, pr)
+		return uiPRMsg{gen: gen, pr: pr, status: status}
+	}
+}
+
+func (m *uiModel) loading() bool {
+	return m.cfg != nil && (m.local == nil && m.localErr == nil || m.ticketLoading || m.prLoading)
+}
+
+func (m *uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.view {
+		case uiViewStart:
+			return m.updateStart(msg)
+		case uiViewProjects:
+			return m.updateProjects(msg)
+		default:
+			return m.updateDashboard(msg)
+		}
+
+	case uiLocalMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.local, m.localErr = msg.state, msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.cursor = 0
+
+		var cmds []tea.Cmd
+		if m.cfg.Ticket != nil && msg.state.ticketID != "" {
+			m.ticketLoading = true
+			cmds = append(cmds, loadUITicket(m.gen, m.cfg, msg.state.ticketID))
+		}
+		if msg.state.branch != m.cfg.Git.BaseBranch {
+			m.prLoading = true
+			cmds = append(cmds, loadUIPR(m.gen, m.cfg, msg.state.branch))
+		}
+		return m, tea.Batch(cmds...)
+
+	case uiTicketMsg:
+		if msg.gen == m.gen {
+			m.ticket, m.ticketErr, m.ticketLoading = msg.ticket, msg.err, false
+		}
+		return m, nil
+
+	case uiPRMsg:
+		if msg.gen == m.gen {
+			m.pr, m.prStatus, m.prErr, m.prLoading = msg.pr, msg.status, msg.err, false
+		}
+		return m, nil
+
+	case uiNoticeMsg:
+		m.message, m.messageErr = msg.text, false
+		if msg.err != nil {
+			m.message, m.messageErr = msg.err.Error(), true
+		}
+		if msg.reload {
+			return m, m.reload()
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.loading() {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *uiModel) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message = ""
+
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+
+	case "w":
+		projects, err := config.ListProjects()
+		if err != nil {
+			return m, notice("", err, false)
+		}
+		if len(projects) == 0 {
+			return m, notice("", fmt.Errorf("no projects configured, run 'one init' to create one"), false)
+		}
+		m.projects, m.projectCursor = projects, 0
+		m.view = uiViewProjects
+		return m, nil
+
+	case "r":
+		return m, m.reload()
+	}
+
+	// Everything below needs a project
+	if m.cfg == nil {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.local != nil && m.cursor < len(m.local.branches)-1 {
+			m.cursor++
+		}
+
+	case "enter":
+		if m.local != nil && m.cursor < len(m.local.branches) {
+			return m, checkoutUIBranch(m.local.branches[m.cursor].Name)
+		}
+
+	case "s":
+		m.input.Reset()
+		m.view = uiViewStart
+		return m, m.input.Focus()
+
+	case "p":
+		if m.local == nil || m.prLoading {
+			return m, nil
+		}
+		if m.pr != nil {
+			return m, openUIURL(m.cfg, m.pr.URL, fmt.Sprintf("Opened PR #%d", m.pr.Number))
+		}
+		if m.local.branch == m.cfg.Git.BaseBranch {
+			return m, notice("", fmt.Errorf("switch to a feature branch to create a PR"), false)
+		}
+		return m, runUIAction("PR submitted", m.createPR(m.local.branch))
+
+	case "t":
+		if m.local == nil || m.local.ticketID == "" || m.cfg.Ticket == nil {
+			return m, notice("", fmt.Errorf("no ticket linked to this branch"), false)
+		}
+		url := template.BuildTicketURL(m.cfg.Ticket.System, m.cfg.Ticket.BaseURL, m.local.ticketID)
+		return m, openUIURL(m.cfg, url, "Opened ticket "+m.local.ticketID)
+	}
+
+	return m, nil
+}
+
+func (m *uiModel) updateStart(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.view = uiViewDashboard
+		return m, nil
+
+	case "enter":
+		ticketID := strings.TrimSpace(m.input.Value())
+		if ticketID == "" {
+			return m, nil
+		}
+		m.view = uiViewDashboard
+
+		cfg := m.cfg
+		ticketID = normalizeTicketID(cfg, ticketID)
+		return m, runUIAction("Started "+ticketID, func() error {
+			return startTask(cfg, ticketID, "")
+		})
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *uiModel) updateProjects(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.view = uiViewDashboard
+
+	case "up", "k":
+		if m.projectCursor > 0 {
+			m.projectCursor--
+		}
+
+	case "down", "j":
+		if m.projectCursor < len(m.projects)-1 {
+			m.projectCursor++
+		}
+
+	case "enter":
+		project := m.projects[m.projectCursor]
+		dirs := project.Directories()
+		if len(dirs) == 0 {
+			m.message, m.messageErr = fmt.Sprintf("no directory of %s exists on this machine", project.Project.Name), true
+			return m, nil
+		}
+		if err := os.Chdir(dirs[0]); err != nil {
+			m.message, m.messageErr = fmt.Sprintf("failed to switch project: %v", err), true
+			return m, nil
+		}
+
+		m.view = uiViewDashboard
+		m.message, m.messageErr = "Switched to "+project.Project.Name, false
+		return m, m.reload()
+	}
+
+	return m, nil
+}
+
+// createPR submits the PR for a branch the way one pr does
+func (m *uiModel) createPR(branch string) func() error {
+	cfg := m.cfg
+	return func() error {
+		repo, err := git.OpenRepository()
+		if err != nil {
+			return err
+		}
+
+		if cfg.Hooks != nil && len(cfg.Hooks.BeforePR) > 0 {
+			if err := hooks.ExecuteHooks(cfg.Hooks.BeforePR, "before_pr"); err != nil {
+				return err
+			}
+		}
+
+		return runPRActual(cfg, repo, branch, prOptions{draft: cfg.Git.DefaultDraft})
+	}
+}
+
+func notice(text string, err error, reload bool) tea.Cmd {
+	return func() tea.Msg { return uiNoticeMsg{text: text, err: err, reload: reload} }
+}
+
+func openUIURL(cfg *config.ProjectConfig, url, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := browser.OpenURL(cfg.Browser.Type, cfg.Browser.Profile, url); err != nil {
+			return uiNoticeMsg{err: fmt.Errorf("failed to open browser: %w", err)}
+		}
+		return uiNoticeMsg{text: text}
+	}
+}
+
+func checkoutUIBranch(branch string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := git.OpenRepository()
+		if err != nil {
+			return uiNoticeMsg{err: err}
+		}
+
+		clean, err := repo.IsClean()
+		if err != nil {
+			return uiNoticeMsg{err: fmt.Errorf("failed to check git status: %w", err)}
+		}
+		if !clean {
+			return uiNoticeMsg{err: fmt.Errorf("working directory is not clean, commit or run 'one start' to stash first")}
+		}
+
+		if err := repo.CheckoutBranch(branch); err != nil {
+			return uiNoticeMsg{err: err}
+		}
+		return uiNoticeMsg{text: "Checked out " + branch, reload: true}
+	}
+}
+
+// runUIAction hands the terminal to a command's internals, which may ask
+// questions of their own, and reloads the dashboard afterwards
+func runUIAction(text string, run func() error) tea.Cmd {
+	return tea.Exec(uiExec{run: run}, func(err error) tea.Msg {
+		return uiNoticeMsg{text: text, err: err, reload: true}
+	})
+}
+
+// uiExec adapts a function to tea.ExecCommand. It waits for enter before
+// returning so the output can be read before the dashboard redraws.
+type uiExec struct {
+	run func() error
+}
+
+func (e uiExec) Run() error {
+	err := e.run()
+	if err != nil {
+		fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(fmt.Sprintf("Error: %v", err)))
+	}
+
+	fmt.Print("\nPress enter to return to the dashboard")
+	_, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+	return err
+}
+
+func (uiExec) SetStdin(io.Reader)  {}
+func (uiExec) SetStdout(io.Writer) {}
+func (uiExec) SetStderr(io.Writer) {}
+
+func (m *uiModel) View() string {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("12")).Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	var b strings.Builder
+	header := titleStyle.Render("one")
+	if m.cfg != nil {
+		header += " " + lipgloss.NewStyle().Bold(true).Render(m.cfg.Project.Name)
+	}
+	if dir, err := os.Getwd(); err == nil {
+		header += dimStyle.Render("  " + dir)
+	}
+	b.WriteString(header + "\n\n")
+
+	switch {
+	case m.view == uiViewProjects:
+		b.WriteString(m.projectsView(width))
+	case m.cfg == nil:
+		b.WriteString(uiPanel("Project", lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.cfgErr.Error())+"\n\n"+
+			dimStyle.Render("Press w to switch to a configured project."), width))
+	case m.localErr != nil:
+		b.WriteString(uiPanel("Project", m.projectBody()+"\n\n"+
+			lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.localErr.Error()), width))
+	default:
+		b.WriteString(m.dashboardView(width))
+	}
+	b.WriteString("\n")
+
+	if m.message != "" {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+		prefix := "✓ "
+		if m.messageErr {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+			prefix = "✗ "
+		}
+		b.WriteString(style.Render(prefix+m.message) + "\n")
+	}
+
+	switch m.view {
+	case uiViewStart:
+		b.WriteString(m.input.View() + "\n")
+		b.WriteString(dimStyle.Render("enter start · esc cancel"))
+	case uiViewProjects:
+		b.WriteString(dimStyle.Render("↑/↓ select · enter switch · esc back"))
+	default:
+		b.WriteString(dimStyle.Render("s start task · p create/open PR · t open ticket · ↑/↓ enter checkout · w switch project · r refresh · q quit"))
+	}
+
+	return b.String()
+}
+
+func (m *uiModel) dashboardView(width int) string {
+	if width < 100 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			uiPanel("Project", m.projectBody(), width),
+			uiPanel("Branch", m.branchBody(), width),
+			uiPanel("Ticket", m.ticketBody(), width),
+			uiPanel("Pull Request", m.prBody(), width),
+			uiPanel("Recent Branches", m.branchesBody(width-4), width),
+		)
+	}
+
+	left := width / 2
+	right := width - left - 1
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left,
+			uiPanel("Project", m.projectBody(), left),
+			uiPanel("Branch", m.branchBody(), left),
+			uiPanel("Ticket", m.ticketBody(), left),
+		),
+		" ",
+		lipgloss.JoinVertical(lipgloss.Left,
+			uiPanel("Pull Request", m.prBody(), right),
+			uiPanel("Recent Branches", m.branchesBody(right-4), right),
+		),
+	)
+}
+
+// uiPanel draws a titled box that is width cells wide
+func uiPanel(title, body string, width int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("8")).
+		Padding(0, 1).
+		Width(width - 2).
+		Render(titleStyle.Render(title) + "\n" + body)
+}
+
+func (m *uiModel) projectBody() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	repo := ""
+	switch {
+	case m.cfg.Git.GitHub != nil:
+		repo = m.cfg.Git.GitHub.Owner + "/" + m.cfg.Git.GitHub.Repo
+	case m.cfg.Git.GitLab != nil:
+		repo = fmt.Sprintf("project %d", m.cfg.Git.GitLab.ProjectID)
+	case m.cfg.Git.Bitbucket != nil:
+		repo = m.cfg.Git.Bitbucket.Workspace + "/" + m.cfg.Git.Bitbucket.RepoSlug
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(m.cfg.Project.Name),
+		fmt.Sprintf("%s %s", m.cfg.Git.Provider, dimStyle.Render(repo)),
+	}
+	if m.cfg.Ticket != nil {
+		lines = append(lines, "tickets: "+m.cfg.Ticket.System)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *uiModel) branchBody() string {
+	if m.local == nil {
+		return m.spinner.View() + " Reading repository..."
+	}
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	state := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ clean")
+	if !m.local.clean {
+		state = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("● uncommitted changes")
+	}
+
+	return lipgloss.NewStyle().Bold(true).Render(m.local.branch) + "\n" +
+		state + "\n" +
+		dimStyle.Render("base: "+m.cfg.Git.Remote+"/"+m.cfg.Git.BaseBranch)
+}
+
+func (m *uiModel) ticketBody() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	switch {
+	case m.cfg.Ticket == nil:
+		return dimStyle.Render("No ticket system configured")
+	case m.local == nil:
+		return m.spinner.View()
+	case m.local.ticketID == "":
+		return dimStyle.Render("No ticket ID in the branch name")
+	case m.ticketLoading:
+		return m.spinner.View() + " Loading " + m.local.ticketID + "..."
+	case m.ticketErr != nil:
+		return lipgloss.NewStyle().Bold(true).Render(m.local.ticketID) + "\n" +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.ticketErr.Error())
+	}
+
+	var details []string
+	for _, field := range []string{m.ticket.Type, m.ticket.State, m.ticket.Priority, m.ticket.Assignee} {
+		if field != "" {
+			details = append(details, field)
+		}
+	}
+
+	body := lipgloss.NewStyle().Bold(true).Render(m.local.ticketID) + " " + m.ticket.Title
+	if len(details) > 0 {
+		body += "\n" + dimStyle.Render(strings.Join(details, " · "))
+	}
+	return body
+}
+
+func (m *uiModel) prBody() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	switch {
+	case m.local == nil:
+		return m.spinner.View()
+	case m.local.branch == m.cfg.Git.BaseBranch:
+		return dimStyle.Render("On the base branch")
+	case m.prLoading:
+		return m.spinner.View() + " Looking for a PR..."
+	case m.prErr != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.prErr.Error())
+	case m.pr == nil:
+		return dimStyle.Render("No open PR, press p to create one")
+	}
+
+	title := fmt.Sprintf("#%d %s", m.pr.Number, m.pr.Title)
+	if m.pr.Draft {
+		title += dimStyle.Render(" (draft)")
+	}
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(title),
+		dimStyle.Render(m.pr.URL),
+	}
+
+	if status := m.prStatus; status != nil {
+		approvals := fmt.Sprint(len(status.Approvals))
+		if status.ApprovalsLeft > 0 {
+			approvals += dimStyle.Render(fmt.Sprintf(" (%d more required)", status.ApprovalsLeft))
+		}
+		lines = append(lines,
+			"",
+			"Checks     "+checksSummary(status.Checks),
+			"Approvals  "+approvals,
+			"Mergeable  "+mergeStateLabel(status),
+		)
+		if len(status.ChangesRequested) > 0 {
+			lines = append(lines, "Changes    "+lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(strings.Join(status.ChangesRequested, ", ")))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// checksSummary counts checks by outcome, e.g. "✓ 4  ✗ 1  ● 2"
+func checksSummary(checks []api.Check) string {
+	if len(checks) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("none reported")
+	}
+
+	var passed, failed, running int
+	for _, check := range checks {
+		switch check.State {
+		case api.CheckSuccess:
+			passed++
+		case api.CheckFailure:
+			failed++
+		case api.CheckPending, api.CheckRunning:
+			running++
+		}
+	}
+
+	var parts []string
+	if passed > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render(fmt.Sprintf("✓ %d passed", passed)))
+	}
+	if failed > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(fmt.Sprintf("✗ %d failed", failed)))
+	}
+	if running > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render(fmt.Sprintf("● %d running", running)))
+	}
+	if len(parts) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(fmt.Sprintf("%d skipped", len(checks)))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m *uiModel) branchesBody(width int) string {
+	if m.local == nil {
+		return m.spinner.View()
+	}
+	if len(m.local.branches) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("No branches yet")
+	}
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+
+	var lines []string
+	for i, branch := range m.local.branches {
+		marker := "  "
+		if branch.Name == m.local.branch {
+			marker = "* "
+		}
+
+		age := relativeAge(branch.Committed)
+		name := truncateText(branch.Name, max(width-len(age)-4, 10))
+		if i == m.cursor {
+			lines = append(lines, selectedStyle.Render("› "+marker+name)+" "+dimStyle.Render(age))
+		} else {
+			lines = append(lines, "  "+marker+name+" "+dimStyle.Render(age))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *uiModel) projectsView(width int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+
+	var lines []string
+	for i, project := range m.projects {
+		line := project.Project.Name
+		if m.cfg != nil && project.Project.Name == m.cfg.Project.Name {
+			line += " (current)"
+		}
+
+		where := dimStyle.Render("no directory on this machine")
+		if dirs := project.Directories(); len(dirs) > 0 {
+			where = dimStyle.Render(dirs[0])
+		}
+
+		if i == m.projectCursor {
+			lines = append(lines, selectedStyle.Render("› "+line)+"  "+where)
+		} else {
+			lines = append(lines, "  "+line+"  "+where)
+		}
+	}
+
+	return uiPanel("Switch Project", strings.Join(lines, "\n"), width)
+}
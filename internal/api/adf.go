@@ -0,0 +1,258 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// adfNode is a node of an Atlassian Document Format document, the rich text
+// format of Jira Cloud REST API v3
+type adfNode struct {
+	Version int                    `json:"version,omitempty"`
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+}
+
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// adfFromText builds an ADF document from plain text. Blank lines separate
+// paragraphs and single newlines become hard breaks.
+func adfFromText(text string) adfNode {
+	doc := adfNode{Version: 1, Type: "doc", Content: []adfNode{}}
+	for _, block := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+
+		paragraph := adfNode{Type: "paragraph"}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				paragraph.Content = append(paragraph.Content, adfNode{Type: "hardBreak"})
+			}
+			if line != "" {
+				paragraph.Content = append(paragraph.Content, adfNode{Type: "text", Text: line})
+			}
+		}
+		doc.Content = append(doc.Content, paragraph)
+	}
+	return doc
+}
+
+// ADFToMarkdown converts an ADF document to Markdown. Nodes without a
+// Markdown equivalent, such as panels or media, are approximated.
+func ADFToMarkdown(raw json.RawMessage) (string, error) {
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", fmt.Errorf("invalid ADF document: %w", err)
+	}
+
+	var b strings.Builder
+	writeADFBlocks(&b, doc.Content, "")
+	return strings.TrimSpace(b.String()), nil
+}
+
+// writeADFBlocks writes block nodes separated by blank lines, each line
+// starting with indent
+func writeADFBlocks(b *strings.Builder, nodes []adfNode, indent string) {
+	for i, node := range nodes {
+		if i > 0 {
+			b.WriteString("\n" + strings.TrimRight(indent, " ") + "\n")
+		}
+		writeADFBlock(b, node, indent)
+	}
+}
+
+func writeADFBlock(b *strings.Builder, node adfNode, indent string) {
+	switch node.Type {
+	case "paragraph":
+		b.WriteString(indent + indentLines(adfInline(node.Content), indent))
+
+	case "heading":
+		level := 1
+		if l, ok := node.Attrs["level"].(float64); ok && l >= 1 && l <= 6 {
+			level = int(l)
+		}
+		b.WriteString(indent + strings.Repeat("#", level) + " " + adfInline(node.Content))
+
+	case "bulletList", "orderedList":
+		for i, item := range node.Content {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			writeADFListItem(b, item, indent, marker)
+		}
+
+	case "codeBlock":
+		lang, _ := node.Attrs["language"].(string)
+		code := adfText(node.Content)
+		b.WriteString(indent + "```" + lang + "\n" + indent + indentLines(code, indent) + "\n" + indent + "```")
+
+	case "blockquote", "panel":
+		var inner strings.Builder
+		writeADFBlocks(&inner, node.Content, "")
+		for i, line := range strings.Split(inner.String(), "\n") {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(strings.TrimRight(indent+"> "+line, " "))
+		}
+
+	case "rule":
+		b.WriteString(indent + "---")
+
+	case "table":
+		writeADFTable(b, node, indent)
+
+	case "mediaSingle", "mediaGroup", "media":
+		b.WriteString(indent + "_[attachment]_")
+
+	case "expand", "nestedExpand":
+		if title, _ := node.Attrs["title"].(string); title != "" {
+			b.WriteString(indent + "**" + title + "**\n\n")
+		}
+		writeADFBlocks(b, node.Content, indent)
+
+	default:
+		// Unknown blocks still carry their text
+		if len(node.Content) > 0 {
+			b.WriteString(indent + adfInline(node.Content))
+		} else if node.Text != "" {
+			b.WriteString(indent + node.Text)
+		}
+	}
+}
+
+// writeADFListItem writes a list item; nested blocks are indented under the
+// marker
+func writeADFListItem(b *strings.Builder, item adfNode, indent, marker string) {
+	childIndent := indent + strings.Repeat(" ", len(marker))
+	for i, child := range item.Content {
+		switch {
+		case i == 0 && child.Type == "paragraph":
+			b.WriteString(indent + marker + indentLines(adfInline(child.Content), childIndent))
+		case i == 0:
+			b.WriteString(indent + marker + "\n")
+			writeADFBlock(b, child, childIndent)
+		default:
+			b.WriteString("\n")
+			writeADFBlock(b, child, childIndent)
+		}
+	}
+}
+
+func writeADFTable(b *strings.Builder, table adfNode, indent string) {
+	for i, row := range table.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			var inner strings.Builder
+			writeADFBlocks(&inner, cell.Content, "")
+			text := strings.ReplaceAll(strings.TrimSpace(inner.String()), "\n", " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "| " + strings.Join(cells, " | ") + " |")
+
+		// Markdown tables need a header row, ADF tables don't
+		if i == 0 {
+			separators := make([]string, len(cells))
+			for j := range separators {
+				separators[j] = "---"
+			}
+			b.WriteString("\n" + indent + "| " + strings.Join(separators, " | ") + " |")
+		}
+	}
+}
+
+// adfInline renders inline nodes with their marks
+func adfInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(applyADFMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			text, _ := node.Attrs["text"].(string)
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			b.WriteString(text)
+		case "emoji":
+			if text, _ := node.Attrs["text"].(string); text != "" {
+				b.WriteString(text)
+			} else if name, _ := node.Attrs["shortName"].(string); name != "" {
+				b.WriteString(name)
+			}
+		case "inlineCard", "blockCard":
+			if url, _ := node.Attrs["url"].(string); url != "" {
+				b.WriteString("<" + url + ">")
+			}
+		case "status", "date":
+			if text, _ := node.Attrs["text"].(string); text != "" {
+				b.WriteString("`" + text + "`")
+			}
+		default:
+			b.WriteString(node.Text)
+			b.WriteString(adfInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+func applyADFMarks(text string, marks []adfMark) string {
+	for _, mark := range marks {
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "_" + text + "_"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "code":
+			text = "`" + text + "`"
+		case "link":
+			if href, _ := mark.Attrs["href"].(string); href != "" {
+				text = "[" + text + "](" + href + ")"
+			}
+		}
+	}
+	return text
+}
+
+// adfText concatenates the text of nodes, ignoring marks
+func adfText(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		if node.Type == "hardBreak" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(node.Text)
+		b.WriteString(adfText(node.Content))
+	}
+	return b.String()
+}
+
+// indentLines indents every line but the first
+func indentLines(text, indent string) string {
+	if indent == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, "\n", "\n"+indent)
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestADFToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "paragraphs and marks",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"bold","marks":[{"type":"strong"}]},
+					{"type":"text","text":", "},
+					{"type":"text","text":"em","marks":[{"type":"em"}]},
+					{"type":"text","text":", "},
+					{"type":"text","text":"gone","marks":[{"type":"strike"}]},
+					{"type":"text","text":", "},
+					{"type":"text","text":"x()","marks":[{"type":"code"}]},
+					{"type":"text","text":" and "},
+					{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}
+				]},
+				{"type":"paragraph","content":[
+					{"type":"text","text":"line one"},{"type":"hardBreak"},{"type":"text","text":"line two"}
+				]}
+			]}`,
+			want: "**bold**, _em_, ~~gone~~, `x()` and [docs](https://example.com)\n\nline one\nline two",
+		},
+		{
+			name: "heading and rule",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"heading","attrs":{"level":3},"content":[{"type":"text","text":"Steps"}]},
+				{"type":"rule"}
+			]}`,
+			want: "### Steps\n\n---",
+		},
+		{
+			name: "nested lists",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[
+						{"type":"paragraph","content":[{"type":"text","text":"one"}]},
+						{"type":"orderedList","content":[
+							{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},
+							{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}
+						]}
+					]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}
+				]}
+			]}`,
+			want: "- one\n  1. first\n  2. second\n- two",
+		},
+		{
+			name: "code block and quote",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1\ny := 2"}]},
+				{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]}
+			]}`,
+			want: "```go\nx := 1\ny := 2\n```\n\n> quoted",
+		},
+		{
+			name: "table",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Key"}]}]},
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Value"}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"a|b"}]}]},
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}
+					]}
+				]}
+			]}`,
+			want: "| Key | Value |\n| --- | --- |\n| a\\|b | 1 |",
+		},
+		{
+			name: "inline nodes",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[
+					{"type":"mention","attrs":{"text":"@Jane"}},
+					{"type":"text","text":" "},
+					{"type":"emoji","attrs":{"shortName":":smile:","text":"😄"}},
+					{"type":"text","text":" "},
+					{"type":"status","attrs":{"text":"DONE"}},
+					{"type":"text","text":" "},
+					{"type":"inlineCard","attrs":{"url":"https://example.com/x"}}
+				]},
+				{"type":"mediaSingle","content":[{"type":"media"}]}
+			]}`,
+			want: "@Jane 😄 `DONE` <https://example.com/x>\n\n_[attachment]_",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ADFToMarkdown(json.RawMessage(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ADFToMarkdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestADFToMarkdownInvalid(t *testing.T) {
+	if _, err := ADFToMarkdown(json.RawMessage(`"text"`)); err == nil {
+		t.Error("expected an error for a document that is not an object")
+	}
+}
+
+func TestADFFromText(t *testing.T) {
+	got := adfFromText("first\nsecond\n\n\nthird\n")
+	want := adfNode{
+		Version: 1,
+		Type:    "doc",
+		Content: []adfNode{
+			{Type: "paragraph", Content: []adfNode{
+				{Type: "text", Text: "first"},
+				{Type: "hardBreak"},
+				{Type: "text", Text: "second"},
+			}},
+			{Type: "paragraph", Content: []adfNode{{Type: "text", Text: "third"}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("adfFromText() = %+v, want %+v", got, want)
+	}
+}
+
+func TestJiraRichText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"missing", ``, ""},
+		{"null", `null`, ""},
+		{"wiki markup is kept", `"*bold*"`, "*bold*"},
+		{"adf", `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi"}]}]}`, "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jiraRichText(json.RawMessage(tt.raw)); got != tt.want {
+				t.Errorf("jiraRichText(%s) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
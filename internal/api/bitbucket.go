@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+const bitbucketBaseURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketClient handles Bitbucket Cloud API operations
+type BitbucketClient struct {
+	token string
+	rest  *restClient
+}
+
+// NewBitbucketClient creates a new Bitbucket Cloud API client. Pass
+// WithBaseURL to point it at another API root, such as an httptest server.
+func NewBitbucketClient(token string, opts ...Option) *BitbucketClient {
+	c := &BitbucketClient{token: token}
+	c.rest = newRESTClient("Bitbucket", bitbucketBaseURL, func(req *http.Request) {
+		req.Header.Set("Authorization", c.authorization())
+	}, decodeBitbucketError, opts)
+	return c
+}
+
+// CreatePullRequest creates a new pull request
+func (c *BitbucketClient) CreatePullRequest(workspace, repoSlug, title, description, sourceBranch, destinationBranch string, draft bool) (*PullRequest, error) {
+	reqBody := map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"source": map[string]interface{}{
+			"branch": map[string]string{"name": sourceBranch},
+		},
+		"destination": map[string]interface{}{
+			"branch": map[string]string{"name": destinationBranch},
+		},
+		"close_source_branch": false,
+		"draft":               draft,
+	}
+
+	var result bitbucketPullRequest
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests", workspace, repoSlug)
+	if _, err := c.rest.do("POST", path, reqBody, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Links.HTML.Href == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	return result.toPullRequest(), nil
+}
+
+// FindPullRequest returns the open pull request whose source is the given
+// branch, or nil if there is none
+func (c *BitbucketClient) FindPullRequest(workspace, repoSlug, sourceBranch string) (*PullRequest, error) {
+	query := neturl.QueryEscape(fmt.Sprintf(`source.branch.name="%s" AND state="OPEN"`, sourceBranch))
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests?q=%s", workspace, repoSlug, query)
+
+	var result struct {
+		Values []bitbucketPullRequest `json:"values"`
+	}
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Values) == 0 {
+		return nil, nil
+	}
+
+	return result.Values[0].toPullRequest(), nil
+}
+
+// UpdatePullRequest replaces the title and description of a pull request
+func (c *BitbucketClient) UpdatePullRequest(workspace, repoSlug string, id int, title, description string) error {
+	return c.updatePullRequest(workspace, repoSlug, id, map[string]interface{}{
+		"title":       title,
+		"description": description,
+	})
+}
+
+// MarkReady clears the draft flag on a pull request
+func (c *BitbucketClient) MarkReady(workspace, repoSlug string, id int) error {
+	return c.updatePullRequest(workspace, repoSlug, id, map[string]interface{}{"draft": false})
+}
+
+// MergePullRequest merges a pull request. The rebase method maps to a
+// fast-forward merge.
+func (c *BitbucketClient) MergePullRequest(workspace, repoSlug string, id int, method string) error {
+	strategy := "merge_commit"
+	switch method {
+	case MergeMethodSquash:
+		strategy = "squash"
+	case MergeMethodRebase:
+		strategy = "fast_forward"
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", workspace, repoSlug, id)
+	_, err := c.rest.do("POST", path, map[string]interface{}{"merge_strategy": strategy}, nil)
+	return err
+}
+
+func (c *BitbucketClient) updatePullRequest(workspace, repoSlug string, id int, fields map[string]interface{}) error {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", workspace, repoSlug, id)
+	_, err := c.rest.do("PUT", path, fields, nil)
+	return err
+}
+
+// ListPullRequestsFor returns the open pull requests in a repository that
+// the user, given by account UUID, authored or was asked to review. Build
+// statuses are not included.
+func (c *BitbucketClient) ListPullRequestsFor(workspace, repoSlug, userUUID string) ([]PullRequestSummary, error) {
+	query := fmt.Sprintf(`state="OPEN" AND (author.uuid="%s" OR reviewers.uuid="%s")`, userUUID, userUUID)
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests?pagelen=50&q=%s", workspace, repoSlug, neturl.QueryEscape(query))
+
+	var result struct {
+		Values []struct {
+			bitbucketPullRequest
+			Author struct {
+				UUID     string `json:"uuid"`
+				Nickname string `json:"nickname"`
+			} `json:"author"`
+		} `json:"values"`
+	}
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequestSummary, 0, len(result.Values))
+	for _, pr := range result.Values {
+		role := RoleReviewer
+		if pr.Author.UUID == userUUID {
+			role = RoleAuthor
+		}
+		prs = append(prs, PullRequestSummary{
+			PullRequest: *pr.toPullRequest(),
+			Author:      pr.Author.Nickname,
+			Role:        role,
+		})
+	}
+	return prs, nil
+}
+
+// authorization builds the Authorization header value. App passwords are
+// stored as "username:app_password" and sent with Basic auth; repository,
+// workspace and OAuth access tokens are sent as Bearer tokens.
+func (c *BitbucketClient) authorization() string {
+	if strings.Contains(c.token, ":") {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.token))
+	}
+	return "Bearer " + c.token
+}
+
+// bitbucketPullRequest is the subset of the Bitbucket pull request payload we use
+type bitbucketPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Draft       bool   `json:"draft"`
+	Source      struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+func (p bitbucketPullRequest) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number: p.ID,
+		Title:  p.Title,
+		Body:   p.Description,
+		URL:    p.Links.HTML.Href,
+		Head:   p.Source.Branch.Name,
+		Base:   p.Destination.Branch.Name,
+		State:  p.State,
+		Draft:  p.Draft,
+	}
+}
+
+// CurrentUser returns the user the token belongs to
+func (c *BitbucketClient) CurrentUser() (*User, error) {
+	var result struct {
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+		UUID        string `json:"uuid"`
+	}
+
+	header, err := c.rest.do("GET", "/user", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &User{
+		Login:  result.Username,
+		Name:   result.DisplayName,
+		ID:     result.UUID,
+		Scopes: parseScopes(header.Get("X-OAuth-Scopes")),
+	}, nil
+}
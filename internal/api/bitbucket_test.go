@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// bitbucketPR is a pull request payload as returned by Bitbucket Cloud
+const bitbucketPR = `{
+  "id": 7,
+  "title": "PROJ-1 Add login",
+  "description": "Adds login",
+  "state": "OPEN",
+  "draft": true,
+  "source": {"branch": {"name": "PROJ-1-add-login"}},
+  "destination": {"branch": {"name": "main"}},
+  "links": {"html": {"href": "https://bitbucket.org/acme/app/pull-requests/7"}}
+}`
+
+func TestBitbucketCreatePullRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		auth  string
+	}{
+		{name: "access token", token: "secret", auth: "Bearer secret"},
+		{name: "app password", token: "jdoe:app-pass", auth: "Basic amRvZTphcHAtcGFzcw=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/repositories/acme/app/pullrequests" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != tt.auth {
+					t.Errorf("Authorization = %q, want %q", got, tt.auth)
+				}
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("invalid request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(bitbucketPR))
+			}))
+			defer server.Close()
+
+			client := NewBitbucketClient(tt.token, WithBaseURL(server.URL))
+			pr, err := client.CreatePullRequest("acme", "app", "PROJ-1 Add login", "Adds login", "PROJ-1-add-login", "main", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := &PullRequest{
+				Number: 7,
+				Title:  "PROJ-1 Add login",
+				Body:   "Adds login",
+				URL:    "https://bitbucket.org/acme/app/pull-requests/7",
+				Head:   "PROJ-1-add-login",
+				Base:   "main",
+				State:  "OPEN",
+				Draft:  true,
+			}
+			if !reflect.DeepEqual(pr, want) {
+				t.Errorf("pull request = %+v, want %+v", pr, want)
+			}
+
+			wantBody := map[string]interface{}{
+				"title":               "PROJ-1 Add login",
+				"description":         "Adds login",
+				"source":              map[string]interface{}{"branch": map[string]interface{}{"name": "PROJ-1-add-login"}},
+				"destination":         map[string]interface{}{"branch": map[string]interface{}{"name": "main"}},
+				"close_source_branch": false,
+				"draft":               true,
+			}
+			if !reflect.DeepEqual(body, wantBody) {
+				t.Errorf("request body = %v, want %v", body, wantBody)
+			}
+		})
+	}
+}
+
+func TestBitbucketFindPullRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *PullRequest
+	}{
+		{
+			name:     "open pull request",
+			response: `{"values": [` + bitbucketPR + `]}`,
+			want: &PullRequest{
+				Number: 7,
+				Title:  "PROJ-1 Add login",
+				Body:   "Adds login",
+				URL:    "https://bitbucket.org/acme/app/pull-requests/7",
+				Head:   "PROJ-1-add-login",
+				Base:   "main",
+				State:  "OPEN",
+				Draft:  true,
+			},
+		},
+		{
+			name:     "no pull request",
+			response: `{"values": []}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" || r.URL.Path != "/repositories/acme/app/pullrequests" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				wantQuery := `source.branch.name="PROJ-1-add-login" AND state="OPEN"`
+				if got := r.URL.Query().Get("q"); got != wantQuery {
+					t.Errorf("q = %q, want %q", got, wantQuery)
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewBitbucketClient("secret", WithBaseURL(server.URL))
+			pr, err := client.FindPullRequest("acme", "app", "PROJ-1-add-login")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(pr, tt.want) {
+				t.Errorf("pull request = %+v, want %+v", pr, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitbucketErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		kind    ErrorKind
+		message string
+		details []string
+	}{
+		{
+			name:    "validation with fields",
+			status:  http.StatusBadRequest,
+			body:    `{"type": "error", "error": {"message": "Bad request", "fields": {"source": ["branch not found"], "destination": ["branch not found"]}}}`,
+			kind:    ErrValidation,
+			message: "Bad request",
+			details: []string{"destination: branch not found", "source: branch not found"},
+		},
+		{
+			name:    "detail string",
+			status:  http.StatusForbidden,
+			body:    `{"type": "error", "error": {"message": "Access denied", "detail": "You must have write access."}}`,
+			kind:    ErrAuth,
+			message: "Access denied",
+			details: []string{"You must have write access."},
+		},
+		{
+			name:   "not json",
+			status: http.StatusNotFound,
+			body:   `<html>Not Found</html>`,
+			kind:   ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewBitbucketClient("secret", WithBaseURL(server.URL))
+			_, err := client.CreatePullRequest("acme", "app", "title", "", "feature", "main", false)
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *Error", err)
+			}
+			if apiErr.Provider != "Bitbucket" || apiErr.StatusCode != tt.status || apiErr.Kind != tt.kind {
+				t.Errorf("error = %+v, want Bitbucket status %d kind %v", apiErr, tt.status, tt.kind)
+			}
+			if tt.message != "" && apiErr.Message != tt.message {
+				t.Errorf("message = %q, want %q", apiErr.Message, tt.message)
+			}
+			if !reflect.DeepEqual(apiErr.Details, tt.details) {
+				t.Errorf("details = %q, want %q", apiErr.Details, tt.details)
+			}
+		})
+	}
+}
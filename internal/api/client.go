@@ -0,0 +1,279 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	userAgent = "one-cli/0.2.0"
+
+	// defaultMaxRetries is how many times a request is retried after the
+	// first attempt
+	defaultMaxRetries = 3
+
+	// maxRateLimitWait caps how long a request sleeps for a rate limit
+	// window to reset before giving up with ErrRateLimited
+	maxRateLimitWait = 60 * time.Second
+)
+
+// restClient is the HTTP layer shared by the provider clients. It encodes
+// JSON bodies, decodes provider error payloads into *Error, retries
+// read-only requests on network errors and 5xx responses, and honors
+// Retry-After and X-RateLimit-* headers.
+type restClient struct {
+	provider    string
+	baseURL     string
+	httpClient  *http.Client
+	authorize   func(req *http.Request)
+	decodeError errorDecoder
+	headers     map[string]string
+	maxRetries  int
+	backoff     time.Duration
+	initErr     error
+}
+
+func newRESTClient(provider, baseURL string, authorize func(*http.Request), decodeError errorDecoder, opts []Option) *restClient {
+	c := &restClient{
+		provider:    provider,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		authorize:   authorize,
+		decodeError: decodeError,
+		headers:     map[string]string{},
+		maxRetries:  defaultMaxRetries,
+		backoff:     500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// do sends a request and decodes a JSON response into out. path is joined
+// to the base URL unless it is already absolute. The response headers are
+// returned for callers that need them (e.g. OAuth scopes).
+func (c *restClient) do(method, path string, body, out interface{}) (http.Header, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = c.baseURL + path
+	}
+
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		payload = data
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if body == nil {
+			req.Body = nil
+			req.ContentLength = 0
+		} else {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		for key, value := range c.headers {
+			req.Header.Set(key, value)
+		}
+		if c.authorize != nil {
+			c.authorize(req)
+		}
+
+		canRetry := attempt < c.maxRetries
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if canRetry && isSafeMethod(method) {
+				time.Sleep(c.backoffFor(attempt))
+				continue
+			}
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			if canRetry && isSafeMethod(method) {
+				time.Sleep(c.backoffFor(attempt))
+				continue
+			}
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if out != nil && len(bytes.TrimSpace(respBody)) > 0 {
+				if err := json.Unmarshal(respBody, out); err != nil {
+					return resp.Header, fmt.Errorf("failed to decode response: %w", err)
+				}
+			}
+			return resp.Header, nil
+		}
+
+		apiErr := c.newError(resp, respBody)
+
+		// Rate limited requests were rejected before doing anything, so they
+		// are safe to repeat regardless of method
+		if apiErr.Kind == ErrRateLimited && canRetry && apiErr.RetryAfter <= maxRateLimitWait {
+			time.Sleep(apiErr.RetryAfter)
+			continue
+		}
+		if apiErr.Kind == ErrServer && canRetry && isSafeMethod(method) {
+			wait := c.backoffFor(attempt)
+			if apiErr.RetryAfter > wait && apiErr.RetryAfter <= maxRateLimitWait {
+				wait = apiErr.RetryAfter
+			}
+			time.Sleep(wait)
+			continue
+		}
+
+		return resp.Header, apiErr
+	}
+}
+
+// graphql runs a GraphQL query against url and decodes its data into out
+func (c *restClient) graphql(url, query string, variables map[string]interface{}, out interface{}) error {
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+
+	_, err := c.do("POST", url, map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}, &envelope)
+	if err != nil {
+		return err
+	}
+
+	if len(envelope.Errors) > 0 {
+		return newGraphQLError(c.provider, http.StatusOK, envelope.Errors)
+	}
+
+	if out != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newError builds an *Error from a failed response
+func (c *restClient) newError(resp *http.Response, body []byte) *Error {
+	apiErr := &Error{
+		Provider:   c.provider,
+		StatusCode: resp.StatusCode,
+		Kind:       classifyStatus(resp.StatusCode),
+	}
+
+	if c.decodeError != nil {
+		apiErr.Message, apiErr.Details = c.decodeError(body)
+	}
+
+	// GraphQL endpoints report failures in an "errors" array
+	if apiErr.Message == "" {
+		var envelope struct {
+			Errors []graphQLError `json:"errors"`
+		}
+		if json.Unmarshal(body, &envelope) == nil && len(envelope.Errors) > 0 {
+			gqlErr := newGraphQLError(c.provider, resp.StatusCode, envelope.Errors)
+			apiErr.Message, apiErr.Details = gqlErr.Message, gqlErr.Details
+			if apiErr.Kind == ErrUnknown || apiErr.Kind == ErrValidation {
+				apiErr.Kind = gqlErr.Kind
+			}
+		}
+	}
+
+	// GitHub reports an exhausted quota as 403 with X-RateLimit-Remaining: 0,
+	// and secondary rate limits as 403 with a Retry-After header
+	wait, limited := rateLimitWait(resp.Header)
+	switch {
+	case limited && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests):
+		apiErr.Kind = ErrRateLimited
+		apiErr.RetryAfter = wait
+	case apiErr.Kind == ErrRateLimited:
+		apiErr.RetryAfter = c.backoff
+	case limited:
+		// 503 with Retry-After: keep the server error but respect the delay
+		apiErr.RetryAfter = wait
+	}
+
+	// GitHub answers duplicate PRs with 422 "A pull request already exists"
+	if apiErr.Kind == ErrValidation && mentionsExisting(apiErr) {
+		apiErr.Kind = ErrConflict
+	}
+
+	return apiErr
+}
+
+// rateLimitWait reads Retry-After and X-RateLimit-* headers and returns how
+// long to wait before the next request
+func rateLimitWait(header http.Header) (time.Duration, bool) {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return time.Until(at), true
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Until(time.Unix(reset, 0))
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// mentionsExisting reports whether a validation error is about a resource
+// that already exists
+func mentionsExisting(err *Error) bool {
+	text := strings.ToLower(err.Message + " " + strings.Join(err.Details, " "))
+	return strings.Contains(text, "already exists")
+}
+
+// backoffFor returns the exponential backoff with jitter for an attempt
+func (c *restClient) backoffFor(attempt int) time.Duration {
+	wait := c.backoff << attempt
+	jitter := time.Duration(rand.Int63n(int64(c.backoff)/2 + 1))
+	return wait + jitter
+}
+
+// isSafeMethod reports whether a request can be safely repeated. Writes are
+// never repeated: a 5xx or dropped connection does not mean a merge or a
+// branch deletion did not happen.
+func isSafeMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	default:
+		return false
+	}
+}
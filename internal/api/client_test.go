@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRESTClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		attempts int
+	}{
+		{"get is retried on server errors", "GET", http.StatusBadGateway, defaultMaxRetries + 1},
+		{"merge is not retried", "PUT", http.StatusBadGateway, 1},
+		{"create is not retried", "POST", http.StatusServiceUnavailable, 1},
+		{"delete is not retried", "DELETE", http.StatusInternalServerError, 1},
+		{"client errors are not retried", "GET", http.StatusNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := newRESTClient("Test", server.URL, nil, nil, nil)
+			client.backoff = 0
+			if _, err := client.do(tt.method, "/merge", nil, nil); err == nil {
+				t.Fatal("expected an error")
+			}
+			if attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies API failures so callers can react to them
+type ErrorKind int
+
+const (
+	ErrUnknown ErrorKind = iota
+	ErrAuth
+	ErrNotFound
+	ErrConflict
+	ErrValidation
+	ErrRateLimited
+	ErrServer
+)
+
+// String returns a short human-readable name for the kind
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrAuth:
+		return "authentication failed"
+	case ErrNotFound:
+		return "not found"
+	case ErrConflict:
+		return "conflict"
+	case ErrValidation:
+		return "validation failed"
+	case ErrRateLimited:
+		return "rate limited"
+	case ErrServer:
+		return "server error"
+	default:
+		return "error"
+	}
+}
+
+// Error is returned by the API clients for non-2xx responses and GraphQL
+// errors. It carries the provider's own message instead of a bare status.
+type Error struct {
+	Provider   string
+	StatusCode int
+	Kind       ErrorKind
+	Message    string
+	Details    []string
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Provider + " API error")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (status %d)", e.StatusCode)
+	}
+
+	msg := e.Message
+	if msg == "" {
+		msg = e.Kind.String()
+	}
+	b.WriteString(": " + msg)
+
+	if len(e.Details) > 0 {
+		b.WriteString(": " + strings.Join(e.Details, "; "))
+	}
+	if e.Kind == ErrRateLimited && e.RetryAfter > 0 {
+		fmt.Fprintf(&b, " (retry in %s)", e.RetryAfter.Round(time.Second))
+	}
+
+	return b.String()
+}
+
+// IsKind reports whether err is an API error of the given kind
+func IsKind(err error, kind ErrorKind) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Kind == kind
+}
+
+// IsAuth reports whether err is an authentication or permission failure
+func IsAuth(err error) bool { return IsKind(err, ErrAuth) }
+
+// IsNotFound reports whether err is a missing resource
+func IsNotFound(err error) bool { return IsKind(err, ErrNotFound) }
+
+// IsConflict reports whether err is a conflict, e.g. a PR that already exists
+func IsConflict(err error) bool { return IsKind(err, ErrConflict) }
+
+// IsRateLimited reports whether err is a rate limit rejection
+func IsRateLimited(err error) bool { return IsKind(err, ErrRateLimited) }
+
+// classifyStatus maps an HTTP status code to an error kind
+func classifyStatus(status int) ErrorKind {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusConflict:
+		return ErrConflict
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= 500:
+		return ErrServer
+	default:
+		return ErrUnknown
+	}
+}
+
+// errorDecoder extracts the message and details from a provider error body
+type errorDecoder func(body []byte) (message string, details []string)
+
+// decodeGitHubError handles {"message": "...", "errors": [...]}
+func decodeGitHubError(body []byte) (string, []string) {
+	var payload struct {
+		Message string            `json:"message"`
+		Errors  []json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return "", nil
+	}
+
+	var details []string
+	for _, raw := range payload.Errors {
+		var item struct {
+			Message  string `json:"message"`
+			Code     string `json:"code"`
+			Field    string `json:"field"`
+			Resource string `json:"resource"`
+		}
+		if json.Unmarshal(raw, &item) != nil {
+			var text string
+			if json.Unmarshal(raw, &text) == nil && text != "" {
+				details = append(details, text)
+			}
+			continue
+		}
+		switch {
+		case item.Message != "":
+			details = append(details, item.Message)
+		case item.Code != "":
+			details = append(details, strings.TrimSpace(fmt.Sprintf("%s %s %s", item.Resource, item.Field, item.Code)))
+		}
+	}
+
+	return payload.Message, details
+}
+
+// decodeGitLabError handles {"message": ...} where message may be a string,
+// a list or a field map, and OAuth-style {"error": "..."} bodies
+func decodeGitLabError(body []byte) (string, []string) {
+	var payload struct {
+		Message          json.RawMessage `json:"message"`
+		Error            string          `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return "", nil
+	}
+
+	if payload.Error != "" {
+		if payload.ErrorDescription != "" {
+			return payload.Error, []string{payload.ErrorDescription}
+		}
+		return payload.Error, nil
+	}
+
+	var text string
+	if json.Unmarshal(payload.Message, &text) == nil {
+		return text, nil
+	}
+
+	var list []string
+	if json.Unmarshal(payload.Message, &list) == nil {
+		return strings.Join(list, "; "), nil
+	}
+
+	var fields map[string][]string
+	if json.Unmarshal(payload.Message, &fields) == nil {
+		return "", flattenFieldErrors(fields)
+	}
+
+	return "", nil
+}
+
+// decodeJiraError handles {"errorMessages": [...], "errors": {field: msg}}
+func decodeJiraError(body []byte) (string, []string) {
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+		Message       string            `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return "", nil
+	}
+
+	message := strings.Join(payload.ErrorMessages, "; ")
+	if message == "" {
+		message = payload.Message
+	}
+
+	fields := make(map[string][]string, len(payload.Errors))
+	for field, msg := range payload.Errors {
+		fields[field] = []string{msg}
+	}
+
+	return message, flattenFieldErrors(fields)
+}
+
+// decodeBitbucketError handles {"type": "error", "error": {"message", "detail"}}
+func decodeBitbucketError(body []byte) (string, []string) {
+	var payload struct {
+		Error struct {
+			Message string              `json:"message"`
+			Detail  json.RawMessage     `json:"detail"`
+			Fields  map[string][]string `json:"fields"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return "", nil
+	}
+
+	var details []string
+	var text string
+	if json.Unmarshal(payload.Error.Detail, &text) == nil && text != "" {
+		details = append(details, text)
+	}
+	details = append(details, flattenFieldErrors(payload.Error.Fields)...)
+
+	return payload.Error.Message, details
+}
+
+// flattenFieldErrors renders field errors as "field: message" in a stable order
+func flattenFieldErrors(fields map[string][]string) []string {
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+
+	var details []string
+	for _, field := range keys {
+		for _, msg := range fields[field] {
+			details = append(details, field+": "+msg)
+		}
+	}
+	return details
+}
+
+// graphQLError is a single entry of a GraphQL "errors" array
+type graphQLError struct {
+	Message    string `json:"message"`
+	Type       string `json:"type"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// newGraphQLError converts GraphQL errors into an API error
+func newGraphQLError(provider string, status int, errs []graphQLError) *Error {
+	apiErr := &Error{
+		Provider:   provider,
+		StatusCode: status,
+		Kind:       ErrUnknown,
+		Message:    errs[0].Message,
+	}
+	for _, e := range errs[1:] {
+		apiErr.Details = append(apiErr.Details, e.Message)
+	}
+
+	code := strings.ToUpper(errs[0].Type + " " + errs[0].Extensions.Code)
+	switch {
+	case strings.Contains(code, "RATE"):
+		apiErr.Kind = ErrRateLimited
+	case strings.Contains(code, "AUTH") || strings.Contains(code, "FORBIDDEN"):
+		apiErr.Kind = ErrAuth
+	case strings.Contains(code, "NOT_FOUND") || strings.Contains(strings.ToLower(apiErr.Message), "not found"):
+		apiErr.Kind = ErrNotFound
+	case strings.Contains(code, "INVALID") || strings.Contains(code, "VALIDATION"):
+		apiErr.Kind = ErrValidation
+	}
+
+	return apiErr
+}
@@ -1,113 +1,708 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
-const githubBaseURL = "https://api.github.com"
+const (
+	githubBaseURL    = "https://api.github.com"
+	githubGraphQLURL = "https://api.github.com/graphql"
+)
 
 // GitHubClient handles GitHub API operations
 type GitHubClient struct {
 	token      string
-	httpClient *http.Client
+	graphqlURL string
+	rest       *restClient
+}
+
+// NewGitHubClient creates a new GitHub API client. Pass WithBaseURL to talk
+// to GitHub Enterprise Server.
+func NewGitHubClient(token string, opts ...Option) *GitHubClient {
+	c := &GitHubClient{token: token}
+	c.rest = newRESTClient("GitHub", githubBaseURL, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}, decodeGitHubError, opts)
+	c.rest.headers["Accept"] = "application/vnd.github+json"
+	c.rest.headers["X-GitHub-Api-Version"] = "2022-11-28"
+	c.graphqlURL = githubGraphQLEndpoint(c.rest.baseURL)
+	return c
 }
 
-// NewGitHubClient creates a new GitHub API client
-func NewGitHubClient(token string) *GitHubClient {
-	return &GitHubClient{
-		token: token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// githubGraphQLEndpoint derives the GraphQL endpoint from the REST root.
+// GitHub Enterprise Server serves REST at /api/v3 and GraphQL at /api/graphql.
+func githubGraphQLEndpoint(restURL string) string {
+	if restURL == githubBaseURL {
+		return githubGraphQLURL
 	}
+	if strings.HasSuffix(restURL, "/api/v3") {
+		return strings.TrimSuffix(restURL, "/v3") + "/graphql"
+	}
+	return restURL + "/graphql"
 }
 
 // CreatePullRequest creates a new pull request
-func (c *GitHubClient) CreatePullRequest(owner, repo, title, body, head, base string) (string, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/pulls", githubBaseURL, owner, repo)
-
+func (c *GitHubClient) CreatePullRequest(owner, repo, title, body, head, base string, draft bool) (*PullRequest, error) {
 	reqBody := map[string]interface{}{
 		"title": title,
 		"body":  body,
 		"head":  head,
 		"base":  base,
-		"draft": false,
+		"draft": draft,
+	}
+
+	var result githubPullRequest
+	if _, err := c.rest.do("POST", fmt.Sprintf("/repos/%s/%s/pulls", owner, repo), reqBody, &result); err != nil {
+		return nil, err
+	}
+
+	if result.HTMLURL == "" {
+		return nil, fmt.Errorf("unexpected response format")
 	}
 
-	data, err := json.Marshal(reqBody)
+	return result.toPullRequest(), nil
+}
+
+// GitHubIssue contains the issue fields used by one
+type GitHubIssue struct {
+	Number   int
+	Title    string
+	Type     string
+	State    string
+	Assignee string
+	Labels   []string
+	HTMLURL  string
+}
+
+// githubIssueResult is the shape of an issue in GitHub responses
+type githubIssueResult struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	HTMLURL  string `json:"html_url"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Type *struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	// PullRequest is set when the issue is a pull request
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+func (r githubIssueResult) toIssue() *GitHubIssue {
+	issue := &GitHubIssue{
+		Number:  r.Number,
+		Title:   r.Title,
+		State:   r.State,
+		HTMLURL: r.HTMLURL,
+	}
+	if r.Assignee != nil {
+		issue.Assignee = r.Assignee.Login
+	}
+	if r.Type != nil {
+		issue.Type = r.Type.Name
+	}
+	for _, label := range r.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	return issue
+}
+
+// GetIssue fetches issue information
+func (c *GitHubClient) GetIssue(owner, repo, issueNumber string) (*GitHubIssue, error) {
+	var result githubIssueResult
+
+	path := fmt.Sprintf("/repos/%s/%s/issues/%s", owner, repo, neturl.PathEscape(issueNumber))
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Title == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	return result.toIssue(), nil
+}
+
+// IssueView fetches the body, sub-issues, closing pull requests and the
+// latest comments of an issue
+func (c *GitHubClient) IssueView(owner, repo, issueNumber string, comments int) (*IssueView, error) {
+	number, err := strconv.Atoi(issueNumber)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("invalid issue number %q", issueNumber)
+	}
+
+	type ref struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		URL    string `json:"url"`
+	}
+	var result struct {
+		Repository struct {
+			Issue *struct {
+				Body      string `json:"body"`
+				SubIssues struct {
+					Nodes []ref `json:"nodes"`
+				} `json:"subIssues"`
+				ClosedByPullRequestsReferences struct {
+					Nodes []ref `json:"nodes"`
+				} `json:"closedByPullRequestsReferences"`
+				Comments struct {
+					Nodes []struct {
+						Author *struct {
+							Login string `json:"login"`
+						} `json:"author"`
+						Body      string    `json:"body"`
+						CreatedAt time.Time `json:"createdAt"`
+					} `json:"nodes"`
+				} `json:"comments"`
+			} `json:"issue"`
+		} `json:"repository"`
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+	err = c.rest.graphql(c.graphqlURL, `query($owner: String!, $repo: String!, $number: Int!, $comments: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      body
+      subIssues(first: 50) { nodes { number title state url } }
+      closedByPullRequestsReferences(first: 20, includeClosedPrs: true) { nodes { number title state url } }
+      comments(last: $comments) { nodes { author { login } body createdAt } }
+    }
+  }
+}`, map[string]interface{}{
+		"owner":    owner,
+		"repo":     repo,
+		"number":   number,
+		"comments": comments,
+	}, &result)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+
+	issue := result.Repository.Issue
+	if issue == nil {
+		return nil, &Error{Provider: "GitHub", Kind: ErrNotFound, Message: fmt.Sprintf("issue #%d not found", number)}
+	}
+
+	view := &IssueView{Description: issue.Body}
+	for _, sub := range issue.SubIssues.Nodes {
+		view.Subtasks = append(view.Subtasks, IssueRef{
+			ID:    fmt.Sprintf("#%d", sub.Number),
+			Title: sub.Title,
+			State: strings.ToLower(sub.State),
+			URL:   sub.URL,
+		})
+	}
+	for _, pr := range issue.ClosedByPullRequestsReferences.Nodes {
+		view.PullRequests = append(view.PullRequests, IssueRef{
+			ID:    fmt.Sprintf("#%d", pr.Number),
+			Title: pr.Title,
+			State: strings.ToLower(pr.State),
+			URL:   pr.URL,
+		})
+	}
+	for _, comment := range issue.Comments.Nodes {
+		entry := IssueComment{Body: comment.Body, Created: comment.CreatedAt}
+		if comment.Author != nil {
+			entry.Author = comment.Author.Login
+		}
+		view.Comments = append(view.Comments, entry)
+	}
+
+	return view, nil
+}
+
+// ListAssignedIssues returns the open issues in a repository assigned to
+// the user, leaving out pull requests
+func (c *GitHubClient) ListAssignedIssues(owner, repo, login string) ([]GitHubIssue, error) {
+	var results []githubIssueResult
+
+	path := fmt.Sprintf("/repos/%s/%s/issues?state=open&assignee=%s&per_page=100", owner, repo, neturl.QueryEscape(login))
+	if _, err := c.rest.do("GET", path, nil, &results); err != nil {
+		return nil, err
+	}
+
+	issues := make([]GitHubIssue, 0, len(results))
+	for _, r := range results {
+		if r.PullRequest != nil {
+			continue
+		}
+		issues = append(issues, *r.toIssue())
+	}
+	return issues, nil
+}
+
+// FindPullRequest returns the open pull request whose head is the given
+// branch, or nil if there is none
+func (c *GitHubClient) FindPullRequest(owner, repo, head string) (*PullRequest, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&head=%s", owner, repo,
+		neturl.QueryEscape(owner+":"+head))
+
+	var result []githubPullRequest
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0].toPullRequest(), nil
+}
+
+// UpdatePullRequest replaces the title and body of a pull request
+func (c *GitHubClient) UpdatePullRequest(owner, repo string, number int, title, body string) error {
+	reqBody := map[string]interface{}{
+		"title": title,
+		"body":  body,
+	}
+
+	_, err := c.rest.do("PATCH", fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), reqBody, nil)
+	return err
+}
+
+// RequestReviewers asks users for a review. Entries of the form org/team
+// request a review from a team.
+func (c *GitHubClient) RequestReviewers(owner, repo string, number int, reviewers []string) error {
+	users, teams := []string{}, []string{}
+	for _, reviewer := range reviewers {
+		if _, team, ok := strings.Cut(reviewer, "/"); ok {
+			teams = append(teams, team)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+
+	reqBody := map[string]interface{}{
+		"reviewers":      users,
+		"team_reviewers": teams,
+	}
+
+	_, err := c.rest.do("POST", fmt.Sprintf("/repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), reqBody, nil)
+	return err
+}
+
+// AddLabels adds labels to a pull request
+func (c *GitHubClient) AddLabels(owner, repo string, number int, labels []string) error {
+	reqBody := map[string]interface{}{
+		"labels": labels,
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", "one-cli/0.2.0")
-	req.Header.Set("Content-Type", "application/json")
+	_, err := c.rest.do("POST", fmt.Sprintf("/repos/%s/%s/issues/%d/labels", owner, repo, number), reqBody, nil)
+	return err
+}
 
-	resp, err := c.httpClient.Do(req)
+// AddAssignees assigns users to a pull request
+func (c *GitHubClient) AddAssignees(owner, repo string, number int, assignees []string) error {
+	reqBody := map[string]interface{}{
+		"assignees": assignees,
+	}
+
+	_, err := c.rest.do("POST", fmt.Sprintf("/repos/%s/%s/issues/%d/assignees", owner, repo, number), reqBody, nil)
+	return err
+}
+
+// SetMilestone adds a pull request to a milestone, given by title or number
+func (c *GitHubClient) SetMilestone(owner, repo string, number int, milestone string) error {
+	id, err := strconv.Atoi(milestone)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		if id, err = c.findMilestone(owner, repo, milestone); err != nil {
+			return err
+		}
+	}
+
+	reqBody := map[string]interface{}{
+		"milestone": id,
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+	_, err = c.rest.do("PATCH", fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number), reqBody, nil)
+	return err
+}
+
+// findMilestone returns the number of the open milestone with the given title
+func (c *GitHubClient) findMilestone(owner, repo, title string) (int, error) {
+	var result []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/milestones?state=open&per_page=100", owner, repo), nil, &result); err != nil {
+		return 0, err
 	}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+	for _, milestone := range result {
+		if strings.EqualFold(milestone.Title, title) {
+			return milestone.Number, nil
+		}
 	}
+	return 0, fmt.Errorf("milestone %q not found", title)
+}
 
-	htmlURL, ok := result["html_url"].(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+// ListLabels returns the names of the repository's labels
+func (c *GitHubClient) ListLabels(owner, repo string) ([]string, error) {
+	var result []struct {
+		Name string `json:"name"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/labels?per_page=100", owner, repo), nil, &result); err != nil {
+		return nil, err
 	}
 
-	return htmlURL, nil
+	labels := make([]string, 0, len(result))
+	for _, label := range result {
+		labels = append(labels, label.Name)
+	}
+	return labels, nil
 }
 
-// GetIssue fetches issue information
-func (c *GitHubClient) GetIssue(owner, repo, issueNumber string) (string, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/issues/%s", githubBaseURL, owner, repo, issueNumber)
+// MergePullRequest merges a pull request with the merge, squash or rebase
+// method
+func (c *GitHubClient) MergePullRequest(owner, repo string, number int, method string) error {
+	reqBody := map[string]interface{}{
+		"merge_method": method,
+	}
+
+	_, err := c.rest.do("PUT", fmt.Sprintf("/repos/%s/%s/pulls/%d/merge", owner, repo, number), reqBody, nil)
+	return err
+}
+
+// EnableAutoMerge merges the pull request once its requirements are met.
+// Like marking a PR ready, this is only available through GraphQL.
+func (c *GitHubClient) EnableAutoMerge(nodeID, method string) error {
+	return c.rest.graphql(c.graphqlURL, `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) {
+    pullRequest { autoMergeRequest { enabledAt } }
+  }
+}`, map[string]interface{}{"id": nodeID, "method": strings.ToUpper(method)}, nil)
+}
+
+// MarkReadyForReview converts a draft pull request into a regular one. The
+// REST API cannot do this, so it goes through GraphQL using the PR node ID.
+func (c *GitHubClient) MarkReadyForReview(nodeID string) error {
+	return c.rest.graphql(c.graphqlURL, `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+    pullRequest { isDraft }
+  }
+}`, map[string]interface{}{"id": nodeID}, nil)
+}
+
+// PullRequestStatus returns the checks, reviews and mergeability of a pull
+// request. Check runs and commit statuses of the head commit are combined.
+func (c *GitHubClient) PullRequestStatus(owner, repo string, number int) (*PullRequestStatus, error) {
+	var pr struct {
+		Draft          bool   `json:"draft"`
+		Mergeable      *bool  `json:"mergeable"`
+		MergeableState string `json:"mergeable_state"`
+		Head           struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), nil, &pr); err != nil {
+		return nil, err
+	}
+
+	status := &PullRequestStatus{
+		Mergeable:   githubMergeState(pr.Draft, pr.Mergeable, pr.MergeableState),
+		MergeDetail: pr.MergeableState,
+	}
+
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=100", owner, repo, pr.Head.SHA), nil, &runs); err != nil {
+		return nil, err
+	}
+	for _, run := range runs.CheckRuns {
+		status.Checks = append(status.Checks, Check{
+			Name:  run.Name,
+			State: githubCheckState(run.Status, run.Conclusion),
+			URL:   run.HTMLURL,
+		})
+	}
+
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, pr.Head.SHA), nil, &combined); err != nil {
+		return nil, err
+	}
+	for _, s := range combined.Statuses {
+		state := s.State
+		if state == "error" {
+			state = CheckFailure
+		}
+		status.Checks = append(status.Checks, Check{Name: s.Context, State: state, URL: s.TargetURL})
+	}
+
+	var reviews []struct {
+		State string `json:"state"`
+		User  struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews?per_page=100", owner, repo, number), nil, &reviews); err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	// A reviewer's latest approval, change request or dismissal counts;
+	// comments leave it as it was
+	latest := map[string]string{}
+	var reviewers []string
+	for _, review := range reviews {
+		if review.State == "COMMENTED" || review.State == "PENDING" {
+			continue
+		}
+		if _, ok := latest[review.User.Login]; !ok {
+			reviewers = append(reviewers, review.User.Login)
+		}
+		latest[review.User.Login] = review.State
+	}
+	for _, login := range reviewers {
+		switch latest[login] {
+		case "APPROVED":
+			status.Approvals = append(status.Approvals, login)
+		case "CHANGES_REQUESTED":
+			status.ChangesRequested = append(status.ChangesRequested, login)
+		}
+	}
+
+	// Review threads are only exposed through GraphQL
+	var threads struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						IsResolved bool `json:"isResolved"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := c.rest.graphql(c.graphqlURL, `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) { nodes { isResolved } }
+    }
+  }
+}`, map[string]interface{}{"owner": owner, "repo": repo, "number": number}, &threads)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	for _, thread := range threads.Repository.PullRequest.ReviewThreads.Nodes {
+		if !thread.IsResolved {
+			status.UnresolvedThreads++
+		}
+	}
+
+	return status, nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", "one-cli/0.2.0")
+// ListPullRequestsFor returns the open pull requests in a repository that
+// the user authored or was asked to review
+func (c *GitHubClient) ListPullRequestsFor(owner, repo, login string) ([]PullRequestSummary, error) {
+	type searchResult struct {
+		Nodes []struct {
+			Number         int    `json:"number"`
+			Title          string `json:"title"`
+			URL            string `json:"url"`
+			IsDraft        bool   `json:"isDraft"`
+			HeadRefName    string `json:"headRefName"`
+			BaseRefName    string `json:"baseRefName"`
+			ReviewDecision string `json:"reviewDecision"`
+			Author         struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							State string `json:"state"`
+						} `json:"statusCheckRollup"`
+					} `json:"commit"`
+				} `json:"nodes"`
+			} `json:"commits"`
+		} `json:"nodes"`
+	}
+	var result struct {
+		Authored searchResult `json:"authored"`
+		Review   searchResult `json:"review"`
+	}
 
-	resp, err := c.httpClient.Do(req)
+	scope := fmt.Sprintf("repo:%s/%s is:pr is:open", owner, repo)
+	err := c.rest.graphql(c.graphqlURL, `query($authored: String!, $review: String!) {
+  authored: search(query: $authored, type: ISSUE, first: 50) { ...prs }
+  review: search(query: $review, type: ISSUE, first: 50) { ...prs }
+}
+fragment prs on SearchResultItemConnection {
+  nodes {
+    ... on PullRequest {
+      number title url isDraft headRefName baseRefName reviewDecision
+      author { login }
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`, map[string]interface{}{
+		"authored": scope + " author:" + login,
+		"review":   scope + " review-requested:" + login,
+	}, &result)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+	var prs []PullRequestSummary
+	seen := map[int]bool{}
+	for _, list := range []struct {
+		role   string
+		result searchResult
+	}{{RoleAuthor, result.Authored}, {RoleReviewer, result.Review}} {
+		for _, node := range list.result.Nodes {
+			if node.Number == 0 || seen[node.Number] {
+				continue
+			}
+			seen[node.Number] = true
+
+			pr := PullRequestSummary{
+				PullRequest: PullRequest{
+					Number: node.Number,
+					Title:  node.Title,
+					URL:    node.URL,
+					Head:   node.HeadRefName,
+					Base:   node.BaseRefName,
+					State:  "open",
+					Draft:  node.IsDraft,
+				},
+				Author: node.Author.Login,
+				Role:   list.role,
+				Review: strings.ToLower(node.ReviewDecision),
+			}
+			if commits := node.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+				pr.Checks = githubRollupState(commits[0].Commit.StatusCheckRollup.State)
+			}
+			prs = append(prs, pr)
+		}
 	}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+	return prs, nil
+}
+
+// githubRollupState maps a status check rollup state to a Check state
+func githubRollupState(state string) string {
+	switch state {
+	case "SUCCESS":
+		return CheckSuccess
+	case "FAILURE", "ERROR":
+		return CheckFailure
+	default: // PENDING, EXPECTED
+		return CheckPending
 	}
+}
+
+// githubCheckState maps a check run's status and conclusion to a Check state
+func githubCheckState(status, conclusion string) string {
+	switch status {
+	case "queued", "waiting", "requested", "pending":
+		return CheckPending
+	case "in_progress":
+		return CheckRunning
+	}
+
+	switch conclusion {
+	case "success":
+		return CheckSuccess
+	case "skipped":
+		return CheckSkipped
+	case "neutral":
+		return CheckNeutral
+	case "cancelled":
+		return CheckCancelled
+	default: // failure, timed_out, action_required, stale
+		return CheckFailure
+	}
+}
+
+// githubMergeState maps mergeable and mergeable_state to a Merge state.
+// GitHub computes mergeability in the background, so it may be unknown.
+func githubMergeState(draft bool, mergeable *bool, state string) string {
+	switch {
+	case draft || state == "draft":
+		return MergeDraft
+	case mergeable == nil || state == "unknown":
+		return MergeChecking
+	case state == "dirty" || !*mergeable:
+		return MergeConflicts
+	case state == "blocked":
+		return MergeBlocked
+	case state == "behind":
+		return MergeBehind
+	default: // clean, unstable, has_hooks
+		return MergeReady
+	}
+}
 
-	title, ok := result["title"].(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+// githubPullRequest is the subset of the GitHub pull request payload we use
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	NodeID  string `json:"node_id"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (p githubPullRequest) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number: p.Number,
+		NodeID: p.NodeID,
+		Title:  p.Title,
+		Body:   p.Body,
+		URL:    p.HTMLURL,
+		Head:   p.Head.Ref,
+		Base:   p.Base.Ref,
+		State:  p.State,
+		Draft:  p.Draft,
+	}
+}
+
+// CurrentUser returns the user the token belongs to. Classic tokens report
+// their scopes in X-OAuth-Scopes; fine-grained tokens report none.
+func (c *GitHubClient) CurrentUser() (*User, error) {
+	var result struct {
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+
+	header, err := c.rest.do("GET", "/user", nil, &result)
+	if err != nil {
+		return nil, err
 	}
 
-	return title, nil
+	return &User{
+		Login:  result.Login,
+		Name:   result.Name,
+		Scopes: parseScopes(header.Get("X-OAuth-Scopes")),
+	}, nil
 }
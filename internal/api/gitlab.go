@@ -1,34 +1,47 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 	"time"
 )
 
 const gitlabBaseURL = "https://gitlab.com/api/v4"
 
+// gitlabRebaseTimeout bounds how long AcceptMergeRequest waits for a
+// rebase, checking every gitlabRebasePoll
+var (
+	gitlabRebaseTimeout = 2 * time.Minute
+	gitlabRebasePoll    = 2 * time.Second
+)
+
 // GitLabClient handles GitLab API operations
 type GitLabClient struct {
-	token      string
-	httpClient *http.Client
+	token string
+	rest  *restClient
 }
 
-// NewGitLabClient creates a new GitLab API client
-func NewGitLabClient(token string) *GitLabClient {
-	return &GitLabClient{
-		token: token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
+// NewGitLabClient creates a new GitLab API client. Pass WithBaseURL to talk
+// to a self-managed instance.
+func NewGitLabClient(token string, opts ...Option) *GitLabClient {
+	c := &GitLabClient{token: token}
+	c.rest = newRESTClient("GitLab", gitlabBaseURL, func(req *http.Request) {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}, decodeGitLabError, opts)
+	return c
 }
 
-// CreateMergeRequest creates a new merge request
-func (c *GitLabClient) CreateMergeRequest(projectID int, title, description, sourceBranch, targetBranch string) (string, error) {
-	url := fmt.Sprintf("%s/projects/%d/merge_requests", gitlabBaseURL, projectID)
+// CreateMergeRequest creates a new merge request. Reviewer and assignee
+// usernames and the milestone title are resolved to their IDs first; those
+// that do not resolve are left out and listed in the result's Warnings, so
+// the merge request is still created.
+func (c *GitLabClient) CreateMergeRequest(projectID int, title, description, sourceBranch, targetBranch string, draft bool, meta PullRequestMetadata) (*PullRequest, error) {
+	// GitLab marks merge requests as drafts through the title prefix
+	if draft && !hasDraftPrefix(title) {
+		title = "Draft: " + title
+	}
 
 	reqBody := map[string]interface{}{
 		"source_branch": sourceBranch,
@@ -37,38 +50,463 @@ func (c *GitLabClient) CreateMergeRequest(projectID int, title, description, sou
 		"description":   description,
 	}
 
-	data, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+	var warnings []string
+	if len(meta.Reviewers) > 0 {
+		ids, skipped := c.UserIDs(meta.Reviewers)
+		if len(skipped) > 0 {
+			warnings = append(warnings, "request reviewers: "+strings.Join(skipped, ", "))
+		}
+		if len(ids) > 0 {
+			reqBody["reviewer_ids"] = ids
+		}
+	}
+	if len(meta.Assignees) > 0 {
+		ids, skipped := c.UserIDs(meta.Assignees)
+		if len(skipped) > 0 {
+			warnings = append(warnings, "add assignees: "+strings.Join(skipped, ", "))
+		}
+		if len(ids) > 0 {
+			reqBody["assignee_ids"] = ids
+		}
+	}
+	if len(meta.Labels) > 0 {
+		reqBody["labels"] = strings.Join(meta.Labels, ",")
+	}
+	if meta.Milestone != "" {
+		if id, err := c.findMilestone(projectID, meta.Milestone); err != nil {
+			warnings = append(warnings, fmt.Sprintf("set milestone: %v", err))
+		} else {
+			reqBody["milestone_id"] = id
+		}
+	}
+
+	var result gitlabMergeRequest
+	if _, err := c.rest.do("POST", fmt.Sprintf("/projects/%d/merge_requests", projectID), reqBody, &result); err != nil {
+		return nil, err
+	}
+
+	if result.WebURL == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	mr := result.toPullRequest()
+	mr.Warnings = warnings
+	return mr, nil
+}
+
+// FindMergeRequest returns the open merge request whose source is the given
+// branch, or nil if there is none
+func (c *GitLabClient) FindMergeRequest(projectID int, sourceBranch string) (*PullRequest, error) {
+	path := fmt.Sprintf("/projects/%d/merge_requests?state=opened&source_branch=%s",
+		projectID, neturl.QueryEscape(sourceBranch))
+
+	var result []gitlabMergeRequest
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0].toPullRequest(), nil
+}
+
+// UpdateMergeRequest replaces the title and description of a merge request,
+// keeping its draft marker
+func (c *GitLabClient) UpdateMergeRequest(projectID int, mr *PullRequest, title, description string) error {
+	if mr.Draft && !hasDraftPrefix(title) {
+		title = "Draft: " + title
+	}
+
+	return c.updateMergeRequest(projectID, mr.Number, map[string]interface{}{
+		"title":       title,
+		"description": description,
+	})
+}
+
+// MarkReady removes the draft marker from a merge request title
+func (c *GitLabClient) MarkReady(projectID int, mr *PullRequest) error {
+	return c.updateMergeRequest(projectID, mr.Number, map[string]interface{}{
+		"title": stripDraftPrefix(mr.Title),
+	})
+}
+
+// AcceptMergeRequest merges a merge request, or with auto set, merges it
+// when its pipeline succeeds. It reports whether the merge happened now.
+// GitLab chooses between merge commits and fast-forward merges per project,
+// so the rebase method rebases the source branch first and then merges it
+// the project's way.
+func (c *GitLabClient) AcceptMergeRequest(projectID, iid int, method string, auto bool) (bool, error) {
+	reqBody := map[string]interface{}{
+		"squash":                       method == MergeMethodSquash,
+		"merge_when_pipeline_succeeds": auto,
+	}
+
+	if method == MergeMethodRebase {
+		sha, err := c.rebaseMergeRequest(projectID, iid)
+		if err != nil {
+			return false, err
+		}
+		// Merge exactly what was rebased
+		reqBody["sha"] = sha
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+	var result gitlabMergeRequest
+	if _, err := c.rest.do("PUT", fmt.Sprintf("/projects/%d/merge_requests/%d/merge", projectID, iid), reqBody, &result); err != nil {
+		return false, err
 	}
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-	req.Header.Set("Content-Type", "application/json")
+	return result.State == "merged", nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+// rebaseMergeRequest rebases the source branch of a merge request onto its
+// target branch and waits for the rebase to finish. It returns the new head
+// commit of the source branch.
+func (c *GitLabClient) rebaseMergeRequest(projectID, iid int) (string, error) {
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, iid)
+	if _, err := c.rest.do("PUT", path+"/rebase", nil, nil); err != nil {
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
+	deadline := time.Now().Add(gitlabRebaseTimeout)
+	for {
+		var result struct {
+			SHA              string `json:"sha"`
+			RebaseInProgress bool   `json:"rebase_in_progress"`
+			MergeError       string `json:"merge_error"`
+		}
+		if _, err := c.rest.do("GET", path+"?include_rebase_in_progress=true", nil, &result); err != nil {
+			return "", err
+		}
+
+		if !result.RebaseInProgress {
+			if result.MergeError != "" {
+				return "", fmt.Errorf("GitLab failed to rebase: %s", result.MergeError)
+			}
+			return result.SHA, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("GitLab is still rebasing after %s", gitlabRebaseTimeout)
+		}
+		time.Sleep(gitlabRebasePoll)
 	}
+}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+// UserIDs resolves usernames to user IDs. Names that cannot be resolved are
+// returned with the reason, e.g. CODEOWNERS groups, which GitLab cannot
+// request as reviewers.
+func (c *GitLabClient) UserIDs(usernames []string) ([]int, []string) {
+	ids := make([]int, 0, len(usernames))
+	var skipped []string
+	for _, username := range usernames {
+		name := strings.TrimPrefix(username, "@")
+		if strings.Contains(name, "/") {
+			skipped = append(skipped, fmt.Sprintf("%s is a group", username))
+			continue
+		}
+
+		var result []struct {
+			ID int `json:"id"`
+		}
+		if _, err := c.rest.do("GET", "/users?username="+neturl.QueryEscape(name), nil, &result); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", username, err))
+			continue
+		}
+		if len(result) == 0 {
+			skipped = append(skipped, fmt.Sprintf("%s not found", username))
+			continue
+		}
+		ids = append(ids, result[0].ID)
 	}
+	return ids, skipped
+}
+
+// findMilestone returns the ID of the active project milestone with the
+// given title
+func (c *GitLabClient) findMilestone(projectID int, title string) (int, error) {
+	var result []struct {
+		ID int `json:"id"`
+	}
+	path := fmt.Sprintf("/projects/%d/milestones?state=active&title=%s", projectID, neturl.QueryEscape(title))
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, fmt.Errorf("milestone %q not found", title)
+	}
+	return result[0].ID, nil
+}
+
+// ListLabels returns the names of the project's labels
+func (c *GitLabClient) ListLabels(projectID int) ([]string, error) {
+	var result []struct {
+		Name string `json:"name"`
+	}
+	if _, err := c.rest.do("GET", fmt.Sprintf("/projects/%d/labels?per_page=100", projectID), nil, &result); err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(result))
+	for _, label := range result {
+		labels = append(labels, label.Name)
+	}
+	return labels, nil
+}
+
+func (c *GitLabClient) updateMergeRequest(projectID, iid int, fields map[string]interface{}) error {
+	_, err := c.rest.do("PUT", fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, iid), fields, nil)
+	return err
+}
 
-	webURL, ok := result["web_url"].(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+// MergeRequestStatus returns the pipeline jobs, approvals and mergeability
+// of a merge request
+func (c *GitLabClient) MergeRequestStatus(projectID, iid int) (*PullRequestStatus, error) {
+	base := fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, iid)
+
+	var mr struct {
+		DetailedMergeStatus string `json:"detailed_merge_status"`
+		HasConflicts        bool   `json:"has_conflicts"`
+		HeadPipeline        *struct {
+			ID int `json:"id"`
+		} `json:"head_pipeline"`
+	}
+	if _, err := c.rest.do("GET", base, nil, &mr); err != nil {
+		return nil, err
+	}
+
+	status := &PullRequestStatus{
+		Mergeable:   gitlabMergeState(mr.DetailedMergeStatus, mr.HasConflicts),
+		MergeDetail: mr.DetailedMergeStatus,
+	}
+
+	if mr.HeadPipeline != nil {
+		var jobs []struct {
+			Name         string `json:"name"`
+			Stage        string `json:"stage"`
+			Status       string `json:"status"`
+			WebURL       string `json:"web_url"`
+			AllowFailure bool   `json:"allow_failure"`
+		}
+		path := fmt.Sprintf("/projects/%d/pipelines/%d/jobs?per_page=100", projectID, mr.HeadPipeline.ID)
+		if _, err := c.rest.do("GET", path, nil, &jobs); err != nil {
+			return nil, err
+		}
+
+		// Jobs come newest first; list them in pipeline order
+		for i := len(jobs) - 1; i >= 0; i-- {
+			job := jobs[i]
+			status.Checks = append(status.Checks, Check{
+				Name:     job.Stage + " / " + job.Name,
+				State:    gitlabJobState(job.Status),
+				URL:      job.WebURL,
+				Optional: job.AllowFailure,
+			})
+		}
+	}
+
+	var approvals struct {
+		ApprovalsLeft int `json:"approvals_left"`
+		ApprovedBy    []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"approved_by"`
+	}
+	if _, err := c.rest.do("GET", base+"/approvals", nil, &approvals); err != nil {
+		return nil, err
+	}
+	status.ApprovalsLeft = approvals.ApprovalsLeft
+	for _, approval := range approvals.ApprovedBy {
+		status.Approvals = append(status.Approvals, approval.User.Username)
+	}
+
+	// Reviewer states need GitLab 15.8 or later, older instances skip them
+	var reviewers []struct {
+		State string `json:"state"`
+		User  struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	if _, err := c.rest.do("GET", base+"/reviewers", nil, &reviewers); err == nil {
+		for _, reviewer := range reviewers {
+			if reviewer.State == "requested_changes" {
+				status.ChangesRequested = append(status.ChangesRequested, reviewer.User.Username)
+			}
+		}
+	}
+
+	var discussions []struct {
+		Notes []struct {
+			Resolvable bool `json:"resolvable"`
+			Resolved   bool `json:"resolved"`
+		} `json:"notes"`
+	}
+	if _, err := c.rest.do("GET", base+"/discussions?per_page=100", nil, &discussions); err != nil {
+		return nil, err
+	}
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if note.Resolvable && !note.Resolved {
+				status.UnresolvedThreads++
+				break
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// ListMergeRequestsFor returns the open merge requests in a project that
+// the user authored or was asked to review
+func (c *GitLabClient) ListMergeRequestsFor(projectID int, username string) ([]PullRequestSummary, error) {
+	var mrs []PullRequestSummary
+	seen := map[int]bool{}
+
+	for _, role := range []string{RoleAuthor, RoleReviewer} {
+		var result []struct {
+			gitlabMergeRequest
+			Author struct {
+				Username string `json:"username"`
+			} `json:"author"`
+		}
+		path := fmt.Sprintf("/projects/%d/merge_requests?state=opened&per_page=50&%s_username=%s",
+			projectID, role, neturl.QueryEscape(username))
+		if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+			return nil, err
+		}
+
+		for _, mr := range result {
+			if seen[mr.IID] {
+				continue
+			}
+			seen[mr.IID] = true
+			mrs = append(mrs, PullRequestSummary{
+				PullRequest: *mr.toPullRequest(),
+				Author:      mr.Author.Username,
+				Role:        role,
+			})
+		}
+	}
+
+	// The list endpoint has neither pipelines nor approvals
+	for i := range mrs {
+		base := fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, mrs[i].Number)
+
+		var mr struct {
+			HeadPipeline *struct {
+				Status string `json:"status"`
+			} `json:"head_pipeline"`
+		}
+		if _, err := c.rest.do("GET", base, nil, &mr); err != nil {
+			return nil, err
+		}
+		if mr.HeadPipeline != nil {
+			mrs[i].Checks = gitlabJobState(mr.HeadPipeline.Status)
+		}
+
+		var approvals struct {
+			Approved      bool `json:"approved"`
+			ApprovalsLeft int  `json:"approvals_left"`
+		}
+		if _, err := c.rest.do("GET", base+"/approvals", nil, &approvals); err != nil {
+			return nil, err
+		}
+		switch {
+		case approvals.Approved && approvals.ApprovalsLeft == 0:
+			mrs[i].Review = ReviewApproved
+		case approvals.ApprovalsLeft > 0:
+			mrs[i].Review = ReviewRequired
+		}
+	}
+
+	return mrs, nil
+}
+
+// gitlabJobState maps a pipeline job status to a Check state
+func gitlabJobState(status string) string {
+	switch status {
+	case "created", "pending", "waiting_for_resource", "preparing", "scheduled":
+		return CheckPending
+	case "running":
+		return CheckRunning
+	case "success":
+		return CheckSuccess
+	case "failed":
+		return CheckFailure
+	case "canceled":
+		return CheckCancelled
+	case "skipped":
+		return CheckSkipped
+	default: // manual
+		return CheckNeutral
+	}
+}
+
+// gitlabMergeState maps detailed_merge_status to a Merge state
+func gitlabMergeState(status string, conflicts bool) string {
+	switch {
+	case conflicts || status == "conflict":
+		return MergeConflicts
+	case status == "mergeable":
+		return MergeReady
+	case status == "draft_status":
+		return MergeDraft
+	case status == "need_rebase":
+		return MergeBehind
+	case status == "checking" || status == "unchecked" || status == "preparing" || status == "":
+		return MergeChecking
+	default: // not_approved, ci_must_pass, discussions_not_resolved, ...
+		return MergeBlocked
+	}
+}
+
+// gitlabMergeRequest is the subset of the GitLab merge request payload we use
+type gitlabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	WebURL       string `json:"web_url"`
+	State        string `json:"state"`
+	Draft        bool   `json:"draft"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+}
+
+func (m gitlabMergeRequest) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number: m.IID,
+		Title:  m.Title,
+		Body:   m.Description,
+		URL:    m.WebURL,
+		Head:   m.SourceBranch,
+		Base:   m.TargetBranch,
+		State:  m.State,
+		Draft:  m.Draft || hasDraftPrefix(m.Title),
+	}
+}
+
+// CurrentUser returns the user the token belongs to. Scopes are only known
+// for personal, project and group access tokens.
+func (c *GitLabClient) CurrentUser() (*User, error) {
+	var result struct {
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	}
+
+	if _, err := c.rest.do("GET", "/user", nil, &result); err != nil {
+		return nil, err
+	}
+
+	user := &User{Login: result.Username, Name: result.Name}
+
+	var token struct {
+		Scopes []string `json:"scopes"`
+	}
+	if _, err := c.rest.do("GET", "/personal_access_tokens/self", nil, &token); err == nil {
+		user.Scopes = token.Scopes
 	}
 
-	return webURL, nil
+	return user, nil
 }
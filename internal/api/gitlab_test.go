@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGitLabAcceptMergeRequest(t *testing.T) {
+	gitlabRebasePoll = 0
+
+	tests := []struct {
+		name     string
+		method   string
+		auto     bool
+		statuses []string // merge request payloads returned while rebasing
+		requests []string
+		body     map[string]interface{}
+		merged   bool
+		wantErr  bool
+	}{
+		{
+			name:     "squash",
+			method:   MergeMethodSquash,
+			requests: []string{"PUT /projects/42/merge_requests/3/merge"},
+			body:     map[string]interface{}{"squash": true, "merge_when_pipeline_succeeds": false},
+			merged:   true,
+		},
+		{
+			name:   "rebase",
+			method: MergeMethodRebase,
+			statuses: []string{
+				`{"rebase_in_progress": true, "sha": "old"}`,
+				`{"rebase_in_progress": false, "sha": "new"}`,
+			},
+			requests: []string{
+				"PUT /projects/42/merge_requests/3/rebase",
+				"GET /projects/42/merge_requests/3",
+				"GET /projects/42/merge_requests/3",
+				"PUT /projects/42/merge_requests/3/merge",
+			},
+			body:   map[string]interface{}{"squash": false, "merge_when_pipeline_succeeds": false, "sha": "new"},
+			merged: true,
+		},
+		{
+			name:     "rebase with auto-merge",
+			method:   MergeMethodRebase,
+			auto:     true,
+			statuses: []string{`{"rebase_in_progress": false, "sha": "new"}`},
+			requests: []string{
+				"PUT /projects/42/merge_requests/3/rebase",
+				"GET /projects/42/merge_requests/3",
+				"PUT /projects/42/merge_requests/3/merge",
+			},
+			body: map[string]interface{}{"squash": false, "merge_when_pipeline_succeeds": true, "sha": "new"},
+		},
+		{
+			name:     "rebase conflict",
+			method:   MergeMethodRebase,
+			statuses: []string{`{"rebase_in_progress": false, "merge_error": "Rebase failed: conflicts"}`},
+			requests: []string{
+				"PUT /projects/42/merge_requests/3/rebase",
+				"GET /projects/42/merge_requests/3",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			statuses := tt.statuses
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch {
+				case r.URL.Path == "/projects/42/merge_requests/3/rebase":
+					w.WriteHeader(http.StatusAccepted)
+					w.Write([]byte(`{"rebase_in_progress": true}`))
+				case r.URL.Path == "/projects/42/merge_requests/3":
+					if r.URL.Query().Get("include_rebase_in_progress") != "true" {
+						t.Errorf("rebase status requested without include_rebase_in_progress")
+					}
+					w.Write([]byte(statuses[0]))
+					statuses = statuses[1:]
+				default:
+					data, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(data, &body); err != nil {
+						t.Errorf("invalid request body: %v", err)
+					}
+					state := "opened"
+					if !tt.auto {
+						state = "merged"
+					}
+					w.Write([]byte(`{"iid": 3, "state": "` + state + `"}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewGitLabClient("secret", WithBaseURL(server.URL))
+			merged, err := client.AcceptMergeRequest(42, 3, tt.method, tt.auto)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if merged != tt.merged {
+				t.Errorf("merged = %v, want %v", merged, tt.merged)
+			}
+			if !reflect.DeepEqual(requests, tt.requests) {
+				t.Errorf("requests = %q, want %q", requests, tt.requests)
+			}
+			if !reflect.DeepEqual(body, tt.body) {
+				t.Errorf("merge body = %v, want %v", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestGitLabCreateMergeRequestMetadata(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users":
+			if r.URL.Query().Get("username") == "alice" {
+				w.Write([]byte(`[{"id": 11}]`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		case "/projects/42/milestones":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "403 Forbidden"}`))
+		case "/projects/42/merge_requests":
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid": 3, "title": "Add login", "web_url": "https://gitlab.com/acme/app/-/merge_requests/3", "state": "opened"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitLabClient("secret", WithBaseURL(server.URL))
+	mr, err := client.CreateMergeRequest(42, "Add login", "", "feature", "main", false, PullRequestMetadata{
+		Reviewers: []string{"@alice", "@acme/backend", "departed"},
+		Assignees: []string{"alice"},
+		Milestone: "v2.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := body["reviewer_ids"], []interface{}{float64(11)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reviewer_ids = %v, want %v", got, want)
+	}
+	if got, want := body["assignee_ids"], []interface{}{float64(11)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("assignee_ids = %v, want %v", got, want)
+	}
+	if _, ok := body["milestone_id"]; ok {
+		t.Error("milestone_id set for a milestone that was not found")
+	}
+	if len(mr.Warnings) != 2 {
+		t.Errorf("warnings = %q, want reviewers and milestone", mr.Warnings)
+	}
+}
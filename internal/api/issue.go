@@ -0,0 +1,39 @@
+package api
+
+import (
+	"regexp"
+	"time"
+)
+
+// IssueRef is a short reference to a sub-task or linked pull request
+type IssueRef struct {
+	ID    string
+	Title string
+	State string
+	URL   string
+}
+
+// IssueComment is a comment on an issue, with its body in Markdown
+type IssueComment struct {
+	Author  string
+	Body    string
+	Created time.Time
+}
+
+// IssueView is the content of an issue beyond its fields, normalized
+// across ticket systems with rich text converted to Markdown
+type IssueView struct {
+	Description  string
+	Subtasks     []IssueRef
+	PullRequests []IssueRef
+	Comments     []IssueComment // latest ones, oldest first
+}
+
+// pullRequestURL matches pull and merge request URLs of the supported git
+// providers
+var pullRequestURL = regexp.MustCompile(`/(pull|pulls|merge_requests|pull-requests)/\d+`)
+
+// isPullRequestURL reports whether a link points to a pull or merge request
+func isPullRequestURL(url string) bool {
+	return pullRequestURL.MatchString(url)
+}
@@ -1,66 +1,422 @@
 package api
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 	"time"
 )
 
+// Jira authentication modes
+const (
+	// JiraAuthCloud sends an Atlassian account email and API token as Basic
+	// credentials
+	JiraAuthCloud = "cloud"
+	// JiraAuthPAT sends a Jira Data Center personal access token as a Bearer
+	// token
+	JiraAuthPAT = "pat"
+	// JiraAuthOAuth sends an OAuth 2.0 (3LO) access token as a Bearer token;
+	// the client must point at the site's api.atlassian.com API root
+	JiraAuthOAuth = "oauth"
+)
+
 // JiraClient handles Jira API operations
 type JiraClient struct {
 	baseURL    string
 	token      string
-	httpClient *http.Client
+	authMode   string
+	apiVersion int
+	rest       *restClient
 }
 
-// NewJiraClient creates a new Jira API client
-func NewJiraClient(baseURL, token string) *JiraClient {
-	return &JiraClient{
-		baseURL: baseURL,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// NewJiraClient creates a new Jira API client. It defaults to Jira Cloud
+// credentials and REST API v2; see WithAuth and WithAPIVersion.
+func NewJiraClient(baseURL, token string, opts ...Option) *JiraClient {
+	c := &JiraClient{
+		baseURL:    baseURL,
+		token:      token,
+		authMode:   JiraAuthCloud,
+		apiVersion: 2,
+	}
+	c.rest = newRESTClient("Jira", baseURL, func(req *http.Request) {
+		req.Header.Set("Authorization", c.authorization())
+	}, decodeJiraError, opts)
+	return c
+}
+
+// WithAuth sets the authentication mode, one of the JiraAuth constants
+func (c *JiraClient) WithAuth(mode string) *JiraClient {
+	if mode != "" {
+		c.authMode = mode
+	}
+	return c
+}
+
+// WithAPIVersion selects REST API v2 or v3. Jira Data Center only has v2;
+// v3 exchanges descriptions and comments as Atlassian Document Format.
+func (c *JiraClient) WithAPIVersion(version int) *JiraClient {
+	if version == 2 || version == 3 {
+		c.apiVersion = version
+	}
+	return c
+}
+
+// authorization builds the Authorization header value. Cloud credentials
+// are "email:api-token", which is encoded here; tokens stored before auth
+// modes existed are already base64 encoded and are sent as they are.
+func (c *JiraClient) authorization() string {
+	switch c.authMode {
+	case JiraAuthPAT, JiraAuthOAuth:
+		return "Bearer " + c.token
 	}
+	if strings.Contains(c.token, ":") {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.token))
+	}
+	return "Basic " + c.token
+}
+
+// path builds a REST API path for the configured API version
+func (c *JiraClient) path(format string, args ...interface{}) string {
+	return fmt.Sprintf("/rest/api/%d", c.apiVersion) + fmt.Sprintf(format, args...)
+}
+
+// JiraIssue contains the issue fields used by one
+type JiraIssue struct {
+	Key      string
+	Summary  string
+	Type     string
+	Priority string
+	Status   string
+	Assignee string
+	Labels   []string
+	// Description is Jira wiki markup with API v2, and Markdown converted
+	// from Atlassian Document Format with v3
+	Description string
+}
+
+// jiraName is the shape of named Jira objects such as status or priority
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+// jiraIssueFields lists the issue fields requested for JiraIssue
+const jiraIssueFields = "summary,issuetype,priority,status,assignee,labels,description"
+
+// jiraIssueResult is the shape of an issue in Jira responses
+type jiraIssueResult struct {
+	Key    string `json:"key"`
+	Fields *struct {
+		Summary   string    `json:"summary"`
+		IssueType *jiraName `json:"issuetype"`
+		Priority  *jiraName `json:"priority"`
+		Status    *jiraName `json:"status"`
+		Labels    []string  `json:"labels"`
+		Assignee  *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		Description json.RawMessage `json:"description"`
+	} `json:"fields"`
+}
+
+func (r jiraIssueResult) toIssue() *JiraIssue {
+	fields := r.Fields
+	issue := &JiraIssue{
+		Key:     r.Key,
+		Summary: fields.Summary,
+		Labels:  fields.Labels,
+	}
+	if fields.IssueType != nil {
+		issue.Type = fields.IssueType.Name
+	}
+	if fields.Priority != nil {
+		issue.Priority = fields.Priority.Name
+	}
+	if fields.Status != nil {
+		issue.Status = fields.Status.Name
+	}
+	if fields.Assignee != nil {
+		issue.Assignee = fields.Assignee.DisplayName
+	}
+	issue.Description = jiraRichText(fields.Description)
+	return issue
 }
 
 // GetIssue fetches issue information
-func (c *JiraClient) GetIssue(issueKey string) (string, error) {
-	url := fmt.Sprintf("%s/rest/api/2/issue/%s", c.baseURL, issueKey)
+func (c *JiraClient) GetIssue(issueKey string) (*JiraIssue, error) {
+	var result jiraIssueResult
+
+	path := c.path("/issue/%s?fields=%s", neturl.PathEscape(issueKey), jiraIssueFields)
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Fields == nil || result.Fields.Summary == "" {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	return result.toIssue(), nil
+}
+
+// SearchIssues returns up to limit issues matching a JQL query. Jira Cloud
+// serves searches from /search/jql, Data Center from /search.
+func (c *JiraClient) SearchIssues(jql string, limit int) ([]JiraIssue, error) {
+	var result struct {
+		Issues []jiraIssueResult `json:"issues"`
+	}
+
+	path := c.path("/search/jql")
+	if c.authMode == JiraAuthPAT {
+		path = c.path("/search")
+	}
+
+	query := neturl.Values{}
+	query.Set("jql", jql)
+	query.Set("fields", jiraIssueFields)
+	query.Set("maxResults", fmt.Sprint(limit))
+	if _, err := c.rest.do("GET", path+"?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]JiraIssue, 0, len(result.Issues))
+	for _, r := range result.Issues {
+		if r.Fields == nil {
+			continue
+		}
+		issues = append(issues, *r.toIssue())
+	}
+	return issues, nil
+}
+
+// IssueView fetches the description, sub-tasks, pull requests linked as
+// remote links and the latest comments of an issue
+func (c *JiraClient) IssueView(issueKey string, comments int) (*IssueView, error) {
+	var issue struct {
+		Fields struct {
+			Description json.RawMessage `json:"description"`
+			Subtasks    []struct {
+				Key    string `json:"key"`
+				Fields struct {
+					Summary string   `json:"summary"`
+					Status  jiraName `json:"status"`
+				} `json:"fields"`
+			} `json:"subtasks"`
+		} `json:"fields"`
+	}
+	path := c.path("/issue/%s?fields=description,subtasks", neturl.PathEscape(issueKey))
+	if _, err := c.rest.do("GET", path, nil, &issue); err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	view := &IssueView{Description: jiraMarkdown(issue.Fields.Description)}
+	for _, task := range issue.Fields.Subtasks {
+		view.Subtasks = append(view.Subtasks, IssueRef{
+			ID:    task.Key,
+			Title: task.Fields.Summary,
+			State: task.Fields.Status.Name,
+		})
+	}
+
+	var links []struct {
+		Object struct {
+			URL   string `json:"url"`
+			Title string `json:"title"`
+		} `json:"object"`
+	}
+	path = c.path("/issue/%s/remotelink", neturl.PathEscape(issueKey))
+	if _, err := c.rest.do("GET", path, nil, &links); err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if isPullRequestURL(link.Object.URL) {
+			view.PullRequests = append(view.PullRequests, IssueRef{Title: link.Object.Title, URL: link.Object.URL})
+		}
+	}
+
+	var result struct {
+		Comments []struct {
+			Author *struct {
+				DisplayName string `json:"displayName"`
+			} `json:"author"`
+			Body    json.RawMessage `json:"body"`
+			Created string          `json:"created"`
+		} `json:"comments"`
+	}
+	path = c.path("/issue/%s/comment?orderBy=-created&maxResults=%d", neturl.PathEscape(issueKey), comments)
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+	// Newest first from the API, oldest first in the view
+	for i := len(result.Comments) - 1; i >= 0; i-- {
+		comment := result.Comments[i]
+		created, _ := time.Parse(jiraTimeLayout, comment.Created)
+		entry := IssueComment{Body: jiraMarkdown(comment.Body), Created: created}
+		if comment.Author != nil {
+			entry.Author = comment.Author.DisplayName
+		}
+		view.Comments = append(view.Comments, entry)
+	}
+
+	return view, nil
+}
+
+// jiraTimeLayout is the timestamp format of Jira responses
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// jiraMarkdown converts a rich text field, wiki markup or ADF, to Markdown
+func jiraMarkdown(raw json.RawMessage) string {
+	var wiki string
+	if json.Unmarshal(raw, &wiki) == nil {
+		return JiraWikiToMarkdown(wiki)
+	}
+	return jiraRichText(raw)
+}
+
+// jiraRichText reads a rich text field, which is a wiki markup string with
+// API v2 and an ADF document with v3
+func jiraRichText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+
+	markdown, err := ADFToMarkdown(raw)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return ""
 	}
+	return markdown
+}
 
-	req.Header.Set("Authorization", "Basic "+c.token)
-	req.Header.Set("Content-Type", "application/json")
+// richText encodes text for a rich text field of the configured API version
+func (c *JiraClient) richText(text string) interface{} {
+	if c.apiVersion >= 3 {
+		return adfFromText(text)
+	}
+	return text
+}
 
-	resp, err := c.httpClient.Do(req)
+// JiraTransition is a workflow transition available on an issue
+type JiraTransition struct {
+	ID   string
+	Name string
+	To   string // name of the status the transition leads to
+}
+
+// Transitions lists the transitions the user can make on an issue
+func (c *JiraClient) Transitions(issueKey string) ([]JiraTransition, error) {
+	var result struct {
+		Transitions []struct {
+			ID   string   `json:"id"`
+			Name string   `json:"name"`
+			To   jiraName `json:"to"`
+		} `json:"transitions"`
+	}
+
+	path := c.path("/issue/%s/transitions", neturl.PathEscape(issueKey))
+	if _, err := c.rest.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	transitions := make([]JiraTransition, 0, len(result.Transitions))
+	for _, t := range result.Transitions {
+		transitions = append(transitions, JiraTransition{ID: t.ID, Name: t.Name, To: t.To.Name})
+	}
+	return transitions, nil
+}
+
+// TransitionIssue moves an issue through the transition with the given
+// name, or the one leading to the status with that name. Workflows differ
+// between projects, so both are accepted, ignoring case.
+func (c *JiraClient) TransitionIssue(issueKey, name string) error {
+	transitions, err := c.Transitions(issueKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return err
+	}
+
+	var match *JiraTransition
+	for i, t := range transitions {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.To, name) {
+			match = &transitions[i]
+			break
+		}
+	}
+	if match == nil {
+		names := make([]string, 0, len(transitions))
+		for _, t := range transitions {
+			names = append(names, t.Name)
+		}
+		return fmt.Errorf("no transition %q on %s (available: %s)", name, issueKey, strings.Join(names, ", "))
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Jira API error: status %d", resp.StatusCode)
+	path := c.path("/issue/%s/transitions", neturl.PathEscape(issueKey))
+	reqBody := map[string]interface{}{
+		"transition": map[string]string{"id": match.ID},
 	}
+	_, err = c.rest.do("POST", path, reqBody, nil)
+	return err
+}
+
+// AddComment posts a comment on an issue
+func (c *JiraClient) AddComment(issueKey, body string) error {
+	path := c.path("/issue/%s/comment", neturl.PathEscape(issueKey))
+	_, err := c.rest.do("POST", path, map[string]interface{}{"body": c.richText(body)}, nil)
+	return err
+}
+
+// AssignIssue assigns an issue to a user as returned by CurrentUser. Jira
+// Cloud assigns by account ID, Jira Server/Data Center by username.
+func (c *JiraClient) AssignIssue(issueKey string, user *User) error {
+	reqBody := map[string]string{"name": user.Login}
+	if user.ID != "" {
+		reqBody = map[string]string{"accountId": user.ID}
+	}
+
+	path := c.path("/issue/%s/assignee", neturl.PathEscape(issueKey))
+	_, err := c.rest.do("PUT", path, reqBody, nil)
+	return err
+}
+
+// AddRemoteLink links a web page, such as a pull request, to an issue. The
+// URL doubles as the link's global ID, so adding it again updates the
+// existing link instead of duplicating it.
+func (c *JiraClient) AddRemoteLink(issueKey, url, title string) error {
+	reqBody := map[string]interface{}{
+		"globalId": url,
+		"object": map[string]string{
+			"url":   url,
+			"title": title,
+		},
+	}
+
+	path := c.path("/issue/%s/remotelink", neturl.PathEscape(issueKey))
+	_, err := c.rest.do("POST", path, reqBody, nil)
+	return err
+}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+// CurrentUser returns the user the token belongs to. Jira Cloud identifies
+// users by email, Jira Server/Data Center by username.
+func (c *JiraClient) CurrentUser() (*User, error) {
+	var result struct {
+		Name         string `json:"name"`
+		AccountID    string `json:"accountId"`
+		EmailAddress string `json:"emailAddress"`
+		DisplayName  string `json:"displayName"`
 	}
 
-	fields, ok := result["fields"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+	if _, err := c.rest.do("GET", c.path("/myself"), nil, &result); err != nil {
+		return nil, err
 	}
 
-	summary, ok := fields["summary"].(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+	login := result.EmailAddress
+	if login == "" {
+		login = result.Name
 	}
 
-	return summary, nil
+	return &User{Login: login, Name: result.DisplayName, ID: result.AccountID}, nil
 }
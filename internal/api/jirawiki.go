@@ -0,0 +1,173 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	wikiHeading   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiList      = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiCodeStart = regexp.MustCompile(`^\{(code|noformat)(?::([^}|]*))?[^}]*\}(.*)$`)
+	wikiTableRow  = regexp.MustCompile(`^\|\|?.*\|$`)
+
+	// wikiProtected matches inline code and links, whose content is not
+	// converted further
+	wikiProtected = regexp.MustCompile(`\{\{.+?\}\}|\[[^\[\]]+\]`)
+	wikiBold      = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	wikiStrike    = regexp.MustCompile(`(^|[\s(])-([^-\s](?:[^-]*[^-\s])?)-($|[\s).,:;!?])`)
+	wikiCitation  = regexp.MustCompile(`\?\?(.+?)\?\?`)
+	wikiImage     = regexp.MustCompile(`![^!\s][^!]*!`)
+	wikiMacro     = regexp.MustCompile(`\{(color|panel|anchor)(:[^}]*)?\}`)
+)
+
+// JiraWikiToMarkdown converts Jira wiki markup, the rich text format of
+// REST API v2, to Markdown. Formatting without a Markdown equivalent, such
+// as colours or panels, is dropped.
+func JiraWikiToMarkdown(wiki string) string {
+	lines := strings.Split(strings.ReplaceAll(wiki, "\r\n", "\n"), "\n")
+
+	var out []string
+	var fence string // closing tag of the current code block
+	var inTable, inQuote bool
+	for _, line := range lines {
+		if fence != "" {
+			if i := strings.Index(line, fence); i >= 0 {
+				if before := line[:i]; strings.TrimSpace(before) != "" {
+					out = append(out, before)
+				}
+				out = append(out, "```")
+				fence = ""
+			} else {
+				out = append(out, line)
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if m := wikiCodeStart.FindStringSubmatch(trimmed); m != nil {
+			out = append(out, "```"+strings.TrimSpace(m[2]))
+			fence = "{" + m[1] + "}"
+			// The block may close on the line it opens
+			if rest := m[3]; rest != "" {
+				if i := strings.Index(rest, fence); i >= 0 {
+					out = append(out, rest[:i], "```")
+					fence = ""
+				} else {
+					out = append(out, rest)
+				}
+			}
+			continue
+		}
+
+		if wikiTableRow.MatchString(trimmed) {
+			header := strings.HasPrefix(trimmed, "||")
+			sep := "|"
+			if header {
+				sep = "||"
+			}
+			cells := strings.Split(strings.Trim(trimmed, "|"), sep)
+			for i, cell := range cells {
+				cells[i] = wikiInline(strings.TrimSpace(strings.Trim(cell, "|")))
+			}
+
+			// Markdown tables need a header row, wiki tables don't
+			separators := "|" + strings.Repeat(" --- |", len(cells))
+			if !inTable && !header {
+				out = append(out, "|"+strings.Repeat("  |", len(cells)), separators)
+			}
+			out = append(out, "| "+strings.Join(cells, " | ")+" |")
+			if !inTable && header {
+				out = append(out, separators)
+			}
+			inTable = true
+			continue
+		}
+		inTable = false
+
+		var converted string
+		switch {
+		case trimmed == "{quote}":
+			if inQuote {
+				out = append(out, "")
+			}
+			inQuote = !inQuote
+			continue
+		case trimmed == "----":
+			converted = "---"
+		case strings.HasPrefix(trimmed, "bq. "):
+			converted = "> " + wikiInline(strings.TrimPrefix(trimmed, "bq. "))
+		default:
+			if m := wikiHeading.FindStringSubmatch(trimmed); m != nil {
+				converted = strings.Repeat("#", int(m[1][0]-'0')) + " " + wikiInline(m[2])
+			} else if m := wikiList.FindStringSubmatch(trimmed); m != nil {
+				converted = wikiListItem(m[1], wikiInline(m[2]))
+			} else {
+				converted = wikiInline(line)
+			}
+		}
+		if inQuote {
+			converted = strings.TrimRight("> "+converted, " ")
+		}
+		out = append(out, converted)
+	}
+	if fence != "" {
+		out = append(out, "```")
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// wikiListItem converts a list marker such as "*#" to an indented Markdown
+// list marker
+func wikiListItem(marker, text string) string {
+	indent := strings.Repeat("    ", len(marker)-1)
+	if strings.HasSuffix(marker, "#") {
+		return indent + "1. " + text
+	}
+	return indent + "- " + text
+}
+
+// wikiInline converts inline formatting, leaving code and link contents
+// alone
+func wikiInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range wikiProtected.FindAllStringIndex(text, -1) {
+		b.WriteString(wikiFormat(text[last:loc[0]]))
+		b.WriteString(wikiToken(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(wikiFormat(text[last:]))
+	return b.String()
+}
+
+// wikiToken converts inline code and links
+func wikiToken(token string) string {
+	if strings.HasPrefix(token, "{{") {
+		return "`" + strings.TrimSuffix(strings.TrimPrefix(token, "{{"), "}}") + "`"
+	}
+
+	inner := strings.TrimSuffix(strings.TrimPrefix(token, "["), "]")
+	switch {
+	case strings.HasPrefix(inner, "~"):
+		return "@" + strings.TrimPrefix(inner, "~")
+	case strings.Contains(inner, "|"):
+		parts := strings.SplitN(inner, "|", 2)
+		return "[" + parts[0] + "](" + strings.TrimSpace(parts[1]) + ")"
+	case strings.Contains(inner, "://") || strings.HasPrefix(inner, "mailto:"):
+		return "<" + inner + ">"
+	default:
+		return token
+	}
+}
+
+func wikiFormat(text string) string {
+	text = wikiImage.ReplaceAllString(text, "_[attachment]_")
+	text = wikiMacro.ReplaceAllString(text, "")
+	text = strings.NewReplacer("{color}", "", "{panel}", "", "{quote}", "").Replace(text)
+	text = wikiBold.ReplaceAllString(text, "**$1**")
+	text = wikiStrike.ReplaceAllString(text, "$1~~$2~~$3")
+	text = wikiCitation.ReplaceAllString(text, "_${1}_")
+	return text
+}
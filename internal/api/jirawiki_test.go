@@ -0,0 +1,99 @@
+package api
+
+import "testing"
+
+func TestJiraWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		wiki string
+		want string
+	}{
+		{
+			name: "headings",
+			wiki: "h1. Title\nh3. Section",
+			want: "# Title\n### Section",
+		},
+		{
+			name: "inline formatting",
+			wiki: "*bold*, _em_, -gone-, ??cite?? and {{x := *y*}}",
+			want: "**bold**, _em_, ~~gone~~, _cite_ and `x := *y*`",
+		},
+		{
+			name: "hyphens are not strikethrough",
+			wiki: "Released 2024-01-02 - a well-known fix",
+			want: "Released 2024-01-02 - a well-known fix",
+		},
+		{
+			name: "links and mentions",
+			wiki: "See [the docs|https://example.com/a_b*c], [https://example.com] and [~jdoe]",
+			want: "See [the docs](https://example.com/a_b*c), <https://example.com> and @jdoe",
+		},
+		{
+			name: "nested lists",
+			wiki: "* one\n** nested *bold*\n# first\n## second\n*# mixed",
+			want: "- one\n    - nested **bold**\n1. first\n    1. second\n    1. mixed",
+		},
+		{
+			name: "code blocks",
+			wiki: "{code:java}\nint x = *y*;\n{code}\n{noformat}\nraw _text_\n{noformat}",
+			want: "```java\nint x = *y*;\n```\n```\nraw _text_\n```",
+		},
+		{
+			name: "single line code block",
+			wiki: "{code}go test ./...{code}",
+			want: "```\ngo test ./...\n```",
+		},
+		{
+			name: "unterminated code block",
+			wiki: "{code}\nx",
+			want: "```\nx\n```",
+		},
+		{
+			name: "table with header",
+			wiki: "||Key||Value||\n|a|*1*|",
+			want: "| Key | Value |\n| --- | --- |\n| a | **1** |",
+		},
+		{
+			name: "table without header",
+			wiki: "|a|b|",
+			want: "|  |  |\n| --- | --- |\n| a | b |",
+		},
+		{
+			name: "quotes",
+			wiki: "{quote}\nquoted\n{quote}\nafter\nbq. short",
+			want: "> quoted\n\nafter\n> short",
+		},
+		{
+			name: "dropped formatting",
+			wiki: "{color:red}red{color} !screenshot.png|thumbnail!\n----",
+			want: "red _[attachment]_\n---",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JiraWikiToMarkdown(tt.wiki); got != tt.want {
+				t.Errorf("JiraWikiToMarkdown(%q) =\n%s\nwant\n%s", tt.wiki, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPullRequestURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/acme/api/pull/12", true},
+		{"https://gitlab.com/acme/api/-/merge_requests/3", true},
+		{"https://bitbucket.org/acme/api/pull-requests/7", true},
+		{"https://github.com/acme/api/issues/12", false},
+		{"https://wiki.acme.com/pull/requests", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPullRequestURL(tt.url); got != tt.want {
+			t.Errorf("isPullRequestURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const linearBaseURL = "https://api.linear.app/graphql"
+
+// LinearClient handles Linear GraphQL API operations
+type LinearClient struct {
+	baseURL string
+	token   string
+	rest    *restClient
+}
+
+// LinearIssue contains the issue fields used by one
+type LinearIssue struct {
+	Identifier string
+	Title      string
+	State      string
+	Assignee   string
+	Priority   string
+	Labels     []string
+	URL        string
+}
+
+// NewLinearClient creates a new Linear API client. Pass WithBaseURL to point
+// it at another GraphQL endpoint, such as an httptest server.
+func NewLinearClient(token string, opts ...Option) *LinearClient {
+	c := &LinearClient{token: token}
+	c.rest = newRESTClient("Linear", linearBaseURL, func(req *http.Request) {
+		req.Header.Set("Authorization", c.authorization())
+	}, nil, opts)
+	c.baseURL = c.rest.baseURL
+	return c
+}
+
+// linearIssueFields selects the fields of LinearIssue
+const linearIssueFields = `identifier
+    title
+    url
+    priorityLabel
+    state { name }
+    assignee { name }
+    labels { nodes { name } }`
+
+const linearIssueQuery = `query Issue($id: String!) {
+  issue(id: $id) {
+    ` + linearIssueFields + `
+  }
+}`
+
+// linearAssignedQuery selects the viewer's issues that are not done or
+// canceled
+const linearAssignedQuery = `query {
+  viewer {
+    assignedIssues(first: 100, filter: { state: { type: { nin: ["completed", "canceled"] } } }) {
+      nodes {
+        ` + linearIssueFields + `
+      }
+    }
+  }
+}`
+
+// linearIssueResult is the shape of an issue in Linear responses
+type linearIssueResult struct {
+	Identifier    string `json:"identifier"`
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	PriorityLabel string `json:"priorityLabel"`
+	State         *struct {
+		Name string `json:"name"`
+	} `json:"state"`
+	Assignee *struct {
+		Name string `json:"name"`
+	} `json:"assignee"`
+	Labels *struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+func (r linearIssueResult) toIssue() *LinearIssue {
+	issue := &LinearIssue{
+		Identifier: r.Identifier,
+		Title:      r.Title,
+		Priority:   r.PriorityLabel,
+		URL:        r.URL,
+	}
+	if r.State != nil {
+		issue.State = r.State.Name
+	}
+	if r.Assignee != nil {
+		issue.Assignee = r.Assignee.Name
+	}
+	if r.Labels != nil {
+		for _, label := range r.Labels.Nodes {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+	}
+	return issue
+}
+
+// GetIssue fetches an issue by its identifier (e.g. ENG-42)
+func (c *LinearClient) GetIssue(identifier string) (*LinearIssue, error) {
+	var result struct {
+		Issue *linearIssueResult `json:"issue"`
+	}
+
+	if err := c.query(linearIssueQuery, map[string]interface{}{"id": identifier}, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Issue == nil {
+		return nil, &Error{Provider: "Linear", Kind: ErrNotFound, Message: fmt.Sprintf("issue %s not found", identifier)}
+	}
+
+	return result.Issue.toIssue(), nil
+}
+
+// AssignedIssues returns the open issues assigned to the user
+func (c *LinearClient) AssignedIssues() ([]LinearIssue, error) {
+	var result struct {
+		Viewer struct {
+			AssignedIssues struct {
+				Nodes []linearIssueResult `json:"nodes"`
+			} `json:"assignedIssues"`
+		} `json:"viewer"`
+	}
+
+	if err := c.query(linearAssignedQuery, nil, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]LinearIssue, 0, len(result.Viewer.AssignedIssues.Nodes))
+	for _, r := range result.Viewer.AssignedIssues.Nodes {
+		issues = append(issues, *r.toIssue())
+	}
+	return issues, nil
+}
+
+const linearIssueViewQuery = `query IssueView($id: String!, $comments: Int!) {
+  issue(id: $id) {
+    description
+    children { nodes { identifier title url state { name } } }
+    attachments { nodes { title url } }
+    comments(last: $comments) { nodes { body createdAt user { name } } }
+  }
+}`
+
+// IssueView fetches the description, sub-issues, pull requests attached by
+// the git integrations and the latest comments of an issue
+func (c *LinearClient) IssueView(identifier string, comments int) (*IssueView, error) {
+	var result struct {
+		Issue *struct {
+			Description string `json:"description"`
+			Children    struct {
+				Nodes []struct {
+					Identifier string `json:"identifier"`
+					Title      string `json:"title"`
+					URL        string `json:"url"`
+					State      *struct {
+						Name string `json:"name"`
+					} `json:"state"`
+				} `json:"nodes"`
+			} `json:"children"`
+			Attachments struct {
+				Nodes []struct {
+					Title string `json:"title"`
+					URL   string `json:"url"`
+				} `json:"nodes"`
+			} `json:"attachments"`
+			Comments struct {
+				Nodes []struct {
+					Body      string    `json:"body"`
+					CreatedAt time.Time `json:"createdAt"`
+					User      *struct {
+						Name string `json:"name"`
+					} `json:"user"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"issue"`
+	}
+
+	variables := map[string]interface{}{"id": identifier, "comments": comments}
+	if err := c.query(linearIssueViewQuery, variables, &result); err != nil {
+		return nil, err
+	}
+
+	issue := result.Issue
+	if issue == nil {
+		return nil, &Error{Provider: "Linear", Kind: ErrNotFound, Message: fmt.Sprintf("issue %s not found", identifier)}
+	}
+
+	view := &IssueView{Description: issue.Description}
+	for _, child := range issue.Children.Nodes {
+		ref := IssueRef{ID: child.Identifier, Title: child.Title, URL: child.URL}
+		if child.State != nil {
+			ref.State = child.State.Name
+		}
+		view.Subtasks = append(view.Subtasks, ref)
+	}
+	for _, attachment := range issue.Attachments.Nodes {
+		if isPullRequestURL(attachment.URL) {
+			view.PullRequests = append(view.PullRequests, IssueRef{Title: attachment.Title, URL: attachment.URL})
+		}
+	}
+	for _, comment := range issue.Comments.Nodes {
+		entry := IssueComment{Body: comment.Body, Created: comment.CreatedAt}
+		if comment.User != nil {
+			entry.Author = comment.User.Name
+		}
+		view.Comments = append(view.Comments, entry)
+	}
+
+	return view, nil
+}
+
+// query executes a GraphQL query and decodes its data into out
+func (c *LinearClient) query(query string, variables map[string]interface{}, out interface{}) error {
+	return c.rest.graphql(c.baseURL, query, variables, out)
+}
+
+// authorization builds the Authorization header value. Personal API keys
+// are sent as-is, OAuth access tokens as Bearer tokens.
+func (c *LinearClient) authorization() string {
+	if strings.HasPrefix(c.token, "lin_api_") {
+		return c.token
+	}
+	return "Bearer " + c.token
+}
+
+// CurrentUser returns the user the token belongs to
+func (c *LinearClient) CurrentUser() (*User, error) {
+	var result struct {
+		Viewer struct {
+			Email string `json:"email"`
+			Name  string `json:"name"`
+		} `json:"viewer"`
+	}
+
+	if err := c.query(`query { viewer { email name } }`, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &User{Login: result.Viewer.Email, Name: result.Viewer.Name}, nil
+}
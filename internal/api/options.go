@@ -0,0 +1,87 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Option configures an API client
+type Option func(*restClient)
+
+// WithBaseURL points the client at a different API root, e.g. a GitHub
+// Enterprise Server (https://git.acme.internal/api/v3), a self-managed GitLab
+// (https://git.acme.internal/api/v4) or an httptest server
+func WithBaseURL(baseURL string) Option {
+	return func(c *restClient) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// WithCACert trusts the PEM certificates in caFile in addition to the system
+// roots, for instances behind a private PKI. Load errors are reported by the
+// first request.
+func WithCACert(caFile string) Option {
+	return func(c *restClient) {
+		if caFile == "" {
+			return
+		}
+		transport, err := caTransport(caFile)
+		if err != nil {
+			c.initErr = err
+			return
+		}
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithTimeout overrides the per-request timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *restClient) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *restClient) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// caTransport builds a transport that trusts the system roots plus caFile
+func caTransport(caFile string) (*http.Transport, error) {
+	if strings.HasPrefix(caFile, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			caFile = home + caFile[1:]
+		}
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", caFile)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return transport, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import "strings"
+
+// PullRequest is a provider-neutral view of a pull or merge request
+type PullRequest struct {
+	Number int    // GitHub/Bitbucket PR number or GitLab MR iid
+	NodeID string // GitHub GraphQL node ID
+	Title  string
+	Body   string
+	URL    string
+	Head   string
+	Base   string
+	State  string
+	Draft  bool
+	// Warnings lists metadata that could not be applied while creating it
+	Warnings []string
+}
+
+// Review decisions of a pull request
+const (
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+	ReviewRequired         = "review_required"
+)
+
+// Roles of the user in a listed pull request
+const (
+	RoleAuthor   = "author"
+	RoleReviewer = "reviewer"
+)
+
+// PullRequestSummary is an open pull request in a listing, with its overall
+// CI and review state
+type PullRequestSummary struct {
+	PullRequest
+	Author string
+	Role   string // RoleAuthor or RoleReviewer
+	Checks string // combined Check* state, empty when there is no CI
+	Review string // one of the Review* decisions, empty when unknown
+}
+
+// Merge methods accepted by the Merge* client methods
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// PullRequestMetadata holds the people, labels and milestone attached to a
+// new pull request. Users are given by username.
+type PullRequestMetadata struct {
+	Reviewers []string // GitHub also accepts org/team
+	Assignees []string
+	Labels    []string
+	Milestone string // title, or number on GitHub
+}
+
+// IsEmpty reports whether no metadata is set
+func (m PullRequestMetadata) IsEmpty() bool {
+	return len(m.Reviewers) == 0 && len(m.Assignees) == 0 && len(m.Labels) == 0 && m.Milestone == ""
+}
+
+// gitlabDraftPrefixes are the title prefixes GitLab uses to mark drafts
+var gitlabDraftPrefixes = []string{"Draft:", "[Draft]", "(Draft)", "WIP:", "[WIP]"}
+
+// hasDraftPrefix reports whether a GitLab MR title marks it as a draft
+func hasDraftPrefix(title string) bool {
+	return stripDraftPrefix(title) != title
+}
+
+// stripDraftPrefix removes a GitLab draft marker from an MR title
+func stripDraftPrefix(title string) string {
+	trimmed := strings.TrimSpace(title)
+	lower := strings.ToLower(trimmed)
+	for _, prefix := range gitlabDraftPrefixes {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return strings.TrimSpace(trimmed[len(prefix):])
+		}
+	}
+	return title
+}
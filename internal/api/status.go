@@ -0,0 +1,68 @@
+package api
+
+// Check states, normalized across providers
+const (
+	CheckPending   = "pending"
+	CheckRunning   = "running"
+	CheckSuccess   = "success"
+	CheckFailure   = "failure"
+	CheckCancelled = "cancelled"
+	CheckSkipped   = "skipped"
+	CheckNeutral   = "neutral"
+)
+
+// Merge states, normalized across providers
+const (
+	MergeReady     = "mergeable"
+	MergeConflicts = "conflicts"
+	MergeBlocked   = "blocked"
+	MergeBehind    = "behind"
+	MergeDraft     = "draft"
+	MergeChecking  = "checking"
+)
+
+// Check is a CI check run, commit status or pipeline job
+type Check struct {
+	Name  string
+	State string // one of the Check* states
+	URL   string
+	// Optional marks GitLab jobs that are allowed to fail
+	Optional bool
+}
+
+// Done reports whether the check has finished
+func (c Check) Done() bool {
+	return c.State != CheckPending && c.State != CheckRunning
+}
+
+// PullRequestStatus is the CI and review state of a pull/merge request
+type PullRequestStatus struct {
+	Checks            []Check
+	Approvals         []string // users who approved
+	ChangesRequested  []string // users who requested changes
+	ApprovalsLeft     int      // approvals still required, where known
+	Mergeable         string   // one of the Merge* states
+	MergeDetail       string   // provider specific reason, e.g. not_approved
+	UnresolvedThreads int
+}
+
+// ChecksDone reports whether every check has finished
+func (s *PullRequestStatus) ChecksDone() bool {
+	for _, check := range s.Checks {
+		if !check.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// FailedChecks returns the checks that failed, leaving out optional ones
+func (s *PullRequestStatus) FailedChecks() []Check {
+	var failed []Check
+	for _, check := range s.Checks {
+		if check.State == CheckFailure && !check.Optional {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
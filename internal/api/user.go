@@ -0,0 +1,22 @@
+package api
+
+import "strings"
+
+// User is the account an API token authenticates as
+type User struct {
+	Login  string
+	Name   string
+	ID     string // Bitbucket account UUID or Jira Cloud account ID
+	Scopes []string
+}
+
+// parseScopes splits a comma separated scopes header such as X-OAuth-Scopes
+func parseScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,265 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	atlassianAuthorizeURL = "https://auth.atlassian.com/authorize"
+	atlassianTokenURL     = "https://auth.atlassian.com/oauth/token"
+	atlassianResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+	atlassianAPIURL       = "https://api.atlassian.com/ex/jira/"
+
+	// DefaultAtlassianCallbackPort is used when no callback port is configured
+	DefaultAtlassianCallbackPort = 8735
+
+	atlassianScopes = "read:jira-work write:jira-work read:jira-user offline_access"
+)
+
+// AtlassianApp identifies an OAuth 2.0 (3LO) app from the Atlassian
+// developer console
+type AtlassianApp struct {
+	ClientID     string
+	ClientSecret string
+	CallbackPort int
+}
+
+func (a AtlassianApp) redirectURI() string {
+	port := a.CallbackPort
+	if port == 0 {
+		port = DefaultAtlassianCallbackPort
+	}
+	return fmt.Sprintf("http://localhost:%d/callback", port)
+}
+
+// atlassianTokenResponse is the response of the token endpoint
+type atlassianTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// AtlassianOAuthFlow performs the OAuth 2.0 authorization code flow for a
+// Jira Cloud site. The user approves access in the browser, which redirects
+// to a local callback server. The token is stored for the project with the
+// API root of the site.
+func AtlassianOAuthFlow(app AtlassianApp, siteURL, projectName string) (*Token, error) {
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	redirect, err := url.Parse(app.redirectURI())
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "localhost:"+redirect.Port())
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the callback server: %w", err)
+	}
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != redirect.Path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") != state:
+			http.Error(w, "Invalid state, please try again.", http.StatusBadRequest)
+			errs <- fmt.Errorf("authorization failed: state mismatch")
+		case query.Get("error") != "":
+			http.Error(w, "Authorization was denied.", http.StatusForbidden)
+			errs <- fmt.Errorf("authorization denied: %s", query.Get("error_description"))
+		default:
+			fmt.Fprintln(w, "Authorized! You can close this window and return to the terminal.")
+			codes <- query.Get("code")
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Shutdown(context.Background())
+
+	params := url.Values{}
+	params.Set("audience", "api.atlassian.com")
+	params.Set("client_id", app.ClientID)
+	params.Set("scope", atlassianScopes)
+	params.Set("redirect_uri", app.redirectURI())
+	params.Set("state", state)
+	params.Set("response_type", "code")
+	params.Set("prompt", "consent")
+
+	fmt.Println()
+	fmt.Printf("🔐 Atlassian Authentication\n\n")
+	fmt.Printf("Please visit: %s\n\n", atlassianAuthorizeURL+"?"+params.Encode())
+	fmt.Println("Waiting for authorization...")
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-errs:
+		return nil, err
+	case <-time.After(5 * time.Minute):
+		return nil, fmt.Errorf("authorization timeout - no response after 5 minutes")
+	}
+
+	token, err := requestAtlassianToken(map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     app.ClientID,
+		"client_secret": app.ClientSecret,
+		"code":          code,
+		"redirect_uri":  app.redirectURI(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token.Resource, err = atlassianResource(token.AccessToken, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := StoreToken("jira", projectName, token); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, nil
+}
+
+// RefreshAtlassianToken exchanges the refresh token of an expired access
+// token for a new one and stores it. Atlassian rotates refresh tokens, so
+// the new one replaces the old.
+func RefreshAtlassianToken(app AtlassianApp, token *Token, projectName string) (*Token, error) {
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("the Jira token has expired, run 'one login jira'")
+	}
+
+	refreshed, err := requestAtlassianToken(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     app.ClientID,
+		"client_secret": app.ClientSecret,
+		"refresh_token": token.RefreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh the Jira token, run 'one login jira': %w", err)
+	}
+
+	refreshed.Resource = token.Resource
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	if err := StoreToken("jira", projectName, refreshed); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// Expired reports whether a token has expired or is about to
+func (t *Token) Expired() bool {
+	return t.ExpiresAt != nil && time.Now().Add(time.Minute).Unix() >= *t.ExpiresAt
+}
+
+func requestAtlassianToken(params map[string]string) (*Token, error) {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(atlassianTokenURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result atlassianTokenResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("unexpected token response (HTTP %d)", resp.StatusCode)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("oauth error: %s %s", result.Error, result.ErrorDescription)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("no access token in response (HTTP %d)", resp.StatusCode)
+	}
+
+	token := &Token{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    "bearer",
+	}
+	if result.ExpiresIn > 0 {
+		expiresAt := time.Now().Unix() + result.ExpiresIn
+		token.ExpiresAt = &expiresAt
+	}
+
+	return token, nil
+}
+
+// atlassianResource finds the API root of the Jira site the token was
+// granted for
+func atlassianResource(accessToken, siteURL string) (string, error) {
+	req, err := http.NewRequest("GET", atlassianResourcesURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var resources []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return "", fmt.Errorf("failed to list accessible Atlassian sites: %w", err)
+	}
+
+	site := strings.TrimSuffix(strings.ToLower(siteURL), "/")
+	var granted []string
+	for _, resource := range resources {
+		if strings.TrimSuffix(strings.ToLower(resource.URL), "/") == site {
+			return atlassianAPIURL + resource.ID, nil
+		}
+		granted = append(granted, resource.URL)
+	}
+
+	return "", fmt.Errorf("access to %s was not granted (granted: %s)", siteURL, strings.Join(granted, ", "))
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -15,6 +15,9 @@ type Token struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 	TokenType    string `json:"token_type"`
 	ExpiresAt    *int64 `json:"expires_at,omitempty"`
+	// Resource is the API root an OAuth token is scoped to, e.g. the
+	// api.atlassian.com URL of a Jira Cloud site
+	Resource string `json:"resource,omitempty"`
 }
 
 // StoreToken stores a token in the system keyring
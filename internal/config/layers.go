@@ -0,0 +1,447 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration is resolved from layers, lowest precedence first:
+//
+//	built-in defaults < ~/.config/one/config.yml < project file < .one.yml < ONE_* environment variables
+//
+// Maps are merged key by key, lists and scalars replace lower layers. Empty
+// strings and nulls do not override lower layers.
+
+// SourceBuiltin is the source recorded for built-in defaults
+const SourceBuiltin = "built-in"
+
+// RepoConfigFile is the name of the optional in-repo override file
+const RepoConfigFile = ".one.yml"
+
+// repoConfigKeys are the keys a .one.yml may set, with their children. A
+// cloned repository must not be able to add hooks, point API URLs at its own
+// host or pick the environment variables tokens are read from, so everything
+// else only comes from the user's own files.
+var repoConfigKeys = []string{
+	"git.base_branch",
+	"git.default_draft",
+	"git.pr",
+	"templates",
+	"branch_patterns",
+}
+
+// builtinDefaults are the values used when no layer sets them
+var builtinDefaults = map[string]interface{}{
+	"git": map[string]interface{}{
+		"remote":      "origin",
+		"base_branch": "main",
+	},
+}
+
+// envOverrides maps ONE_* environment variables to config keys
+var envOverrides = []struct {
+	Env  string
+	Key  string
+	Bool bool
+}{
+	{Env: "ONE_GIT_PROVIDER", Key: "git.provider"},
+	{Env: "ONE_GIT_REMOTE", Key: "git.remote"},
+	{Env: "ONE_GIT_BASE_BRANCH", Key: "git.base_branch"},
+	{Env: "ONE_GIT_DEFAULT_DRAFT", Key: "git.default_draft", Bool: true},
+	{Env: "ONE_BROWSER_TYPE", Key: "browser.type"},
+	{Env: "ONE_BROWSER_PROFILE", Key: "browser.profile"},
+	{Env: "ONE_TICKET_SYSTEM", Key: "ticket.system"},
+	{Env: "ONE_TICKET_BASE_URL", Key: "ticket.base_url"},
+}
+
+// layer is one source of configuration values
+type layer struct {
+	source string
+	values map[string]interface{}
+}
+
+// GetGlobalConfigPath returns the path of the global configuration file
+func GetGlobalConfigPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.yml"), nil
+}
+
+// LoadGlobalConfig loads the global configuration. A missing file yields an
+// empty configuration.
+func LoadGlobalConfig() (*GlobalConfig, error) {
+	path, err := GetGlobalConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var global GlobalConfig
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &global, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read global config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &global); err != nil {
+		return nil, fmt.Errorf("failed to parse global config %s: %w", path, err)
+	}
+
+	return &global, nil
+}
+
+// resolveProjectConfig loads a project file with its layers merged in. When
+// workDir is set, the .one.yml of its repository and ONE_* environment
+// variables are applied as well.
+func resolveProjectConfig(path, workDir string) (*ProjectConfig, error) {
+	layers := []layer{{source: SourceBuiltin, values: builtinDefaults}}
+
+	global, err := globalLayer()
+	if err != nil {
+		return nil, err
+	}
+	if global != nil {
+		layers = append(layers, *global)
+	}
+
+	project, err := readLayer(path)
+	if err != nil {
+		return nil, err
+	}
+	layers = append(layers, layer{source: path, values: project})
+
+	if workDir != "" {
+		if repoFile := findRepoConfig(workDir); repoFile != "" {
+			values, err := readLayer(repoFile)
+			if err != nil {
+				return nil, err
+			}
+			// Keys outside the allow-list are dropped here and reported by
+			// one config validate
+			values, _ = splitRepoValues(values, "")
+			if err := checkRepoBodyFile(values, repoFile); err != nil {
+				delete(values["templates"].(map[string]interface{}), "pr_body_file")
+			}
+			layers = append(layers, layer{source: repoFile, values: values})
+		}
+
+		env, err := envLayers()
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, env...)
+	}
+
+	merged := map[string]interface{}{}
+	sources := map[string]string{}
+	for _, l := range layers {
+		mergeValues(merged, l.values, l.source, "", sources)
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	var config ProjectConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse merged config for %s: %w", path, err)
+	}
+	config.sources = sources
+
+	return &config, nil
+}
+
+// globalLayer maps the global defaults onto the project config shape
+func globalLayer() (*layer, error) {
+	global, err := LoadGlobalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(global.Defaults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read global defaults: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to read global defaults: %w", err)
+	}
+
+	path, _ := GetGlobalConfigPath()
+	return &layer{source: path, values: values}, nil
+}
+
+// readLayer reads a YAML file into a generic map
+func readLayer(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// envLayers collects the ONE_* environment variable overrides, one layer
+// per variable so each value records the variable it came from
+func envLayers() ([]layer, error) {
+	var layers []layer
+	for _, override := range envOverrides {
+		raw := os.Getenv(override.Env)
+		if raw == "" {
+			continue
+		}
+
+		var value interface{} = raw
+		if override.Bool {
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %q is not a boolean", override.Env, raw)
+			}
+			value = b
+		}
+
+		values := map[string]interface{}{}
+		setPath(values, override.Key, value)
+		layers = append(layers, layer{source: "$" + override.Env, values: values})
+	}
+
+	return layers, nil
+}
+
+// repoKeyAllowed reports whether a .one.yml may set the dotted key
+func repoKeyAllowed(key string) bool {
+	for _, allowed := range repoConfigKeys {
+		if key == allowed || strings.HasPrefix(key, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// repoKeyParent reports whether an allowed key lies under the dotted key
+func repoKeyParent(key string) bool {
+	for _, allowed := range repoConfigKeys {
+		if strings.HasPrefix(allowed, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRepoValues keeps the .one.yml values that are allowed and returns the
+// dotted keys of the rest, sorted
+func splitRepoValues(values map[string]interface{}, prefix string) (map[string]interface{}, []string) {
+	allowed := map[string]interface{}{}
+	var rejected []string
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if repoKeyAllowed(path) {
+			allowed[key] = value
+			continue
+		}
+
+		if child, ok := value.(map[string]interface{}); ok && repoKeyParent(path) {
+			kept, childRejected := splitRepoValues(child, path)
+			if len(kept) > 0 {
+				allowed[key] = kept
+			}
+			rejected = append(rejected, childRejected...)
+			continue
+		}
+
+		rejected = append(rejected, path)
+	}
+
+	sort.Strings(rejected)
+	return allowed, rejected
+}
+
+// checkRepoBodyFile checks the templates.pr_body_file of a .one.yml. It must
+// name a file inside the repository, so a cloned repository cannot have the
+// user's own files posted into a PR body.
+func checkRepoBodyFile(values map[string]interface{}, repoFile string) error {
+	templates, _ := values["templates"].(map[string]interface{})
+	path, _ := templates["pr_body_file"].(string)
+	if path == "" {
+		return nil
+	}
+	if strings.HasPrefix(path, "~") || filepath.IsAbs(path) {
+		return fmt.Errorf("pr_body_file must be relative to the repository root in %s", RepoConfigFile)
+	}
+
+	// Resolve symlinks so a link committed to the repository cannot point
+	// outside of it either
+	root := findRepoRoot(filepath.Dir(repoFile))
+	target := filepath.Join(root, path)
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+		if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolvedRoot
+		}
+	}
+
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("pr_body_file must stay inside the repository in %s", RepoConfigFile)
+	}
+	return nil
+}
+
+// findRepoRoot returns the repository root above dir, or dir itself when it
+// is not in a repository
+func findRepoRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// findRepoConfig looks for a .one.yml from dir up to the repository root
+func findRepoConfig(dir string) string {
+	dir = normalizePath(dir)
+	for {
+		candidate := filepath.Join(dir, RepoConfigFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+
+		// Stop at the repository root
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// mergeValues merges src into dst, recording the source of every leaf value
+func mergeValues(dst, src map[string]interface{}, source, prefix string, sources map[string]string) {
+	for key, value := range src {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if value == nil || value == "" {
+			continue
+		}
+
+		if child, ok := value.(map[string]interface{}); ok {
+			existing, ok := dst[key].(map[string]interface{})
+			if !ok {
+				existing = map[string]interface{}{}
+				dst[key] = existing
+			}
+			mergeValues(existing, child, source, path, sources)
+			continue
+		}
+
+		dst[key] = value
+		sources[path] = source
+	}
+}
+
+// setPath sets a dotted key in a nested map
+func setPath(values map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := values[part].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			values[part] = child
+		}
+		values = child
+	}
+	values[parts[len(parts)-1]] = value
+}
+
+// Sources maps dotted config keys (e.g. git.base_branch) to the layer that
+// set them: "built-in", a file path or an environment variable such as
+// $ONE_GIT_REMOTE
+func (c *ProjectConfig) Sources() map[string]string {
+	return c.sources
+}
+
+// AnnotatedYAML renders the configuration with a comment on every value
+// naming the layer it came from
+func (c *ProjectConfig) AnnotatedYAML() ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(c); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+
+	annotate(&doc, "", c.sources)
+
+	data, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	return data, nil
+}
+
+// annotate sets line comments on the values of a node tree
+func annotate(node *yaml.Node, prefix string, sources map[string]string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+
+		if value.Kind == yaml.MappingNode {
+			annotate(value, path, sources)
+			continue
+		}
+
+		if source, ok := sources[path]; ok {
+			comment := "from " + displaySource(source)
+			if value.Kind == yaml.ScalarNode {
+				value.LineComment = comment
+			} else {
+				key.LineComment = comment
+			}
+		}
+	}
+}
+
+// displaySource shortens file sources under the home directory
+func displaySource(source string) string {
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(source, home+string(filepath.Separator)) {
+		return "~" + strings.TrimPrefix(source, home)
+	}
+	return source
+}
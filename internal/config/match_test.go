@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchPathPattern(t *testing.T) {
+	root := normalizePath(t.TempDir())
+	for _, dir := range []string{"work/api/cmd", "work/web", "workshop"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		dir     string
+		depth   int
+		glob    bool
+		ok      bool
+	}{
+		{"exact directory", root + "/work/api", root + "/work/api", pathDepth(root) + 2, false, true},
+		{"subdirectory", root + "/work", root + "/work/api/cmd", pathDepth(root) + 1, false, true},
+		{"trailing slash", root + "/work/", root + "/work/web", pathDepth(root) + 1, false, true},
+		{"sibling with shared prefix", root + "/work", root + "/workshop", 0, false, false},
+		{"glob", root + "/work/*", root + "/work/web", pathDepth(root) + 2, true, true},
+		{"glob matches a parent", root + "/work/*", root + "/work/api/cmd", pathDepth(root) + 2, true, true},
+		{"glob without match", root + "/other/*", root + "/work/web", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depth, glob, ok := matchPathPattern(tt.pattern, tt.dir)
+			if depth != tt.depth || glob != tt.glob || ok != tt.ok {
+				t.Errorf("matchPathPattern(%q, %q) = %d, %v, %v, want %d, %v, %v",
+					tt.pattern, tt.dir, depth, glob, ok, tt.depth, tt.glob, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMatchRemotePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		remote  string
+		depth   int
+		glob    bool
+		ok      bool
+	}{
+		{"repository", "github.com/acme/api", "git@github.com:acme/api.git", 3, false, true},
+		{"owner", "github.com/acme", "https://github.com/acme/api.git", 2, false, true},
+		{"owner glob", "github.com/acme/*", "https://github.com/acme/web", 3, true, true},
+		{"clone URL", "git@github.com:acme/api.git", "https://github.com/acme/api", 3, false, true},
+		{"case insensitive", "GitHub.com/Acme/API", "git@github.com:acme/api.git", 3, false, true},
+		{"owner prefix", "github.com/acme", "git@github.com:acme-labs/api.git", 0, false, false},
+		{"other host", "gitlab.com/acme", "git@github.com:acme/api.git", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depth, glob, ok := matchRemotePattern(tt.pattern, tt.remote)
+			if depth != tt.depth || glob != tt.glob || ok != tt.ok {
+				t.Errorf("matchRemotePattern(%q, %q) = %d, %v, %v, want %d, %v, %v",
+					tt.pattern, tt.remote, depth, glob, ok, tt.depth, tt.glob, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMoreSpecific(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b ProjectMatch
+		want bool
+	}{
+		{"path beats remote", ProjectMatch{Kind: MatchPath, depth: 1}, ProjectMatch{Kind: MatchRemote, depth: 3}, true},
+		{"remote loses to path", ProjectMatch{Kind: MatchRemote, depth: 3}, ProjectMatch{Kind: MatchPath, depth: 1}, false},
+		{"deeper wins", ProjectMatch{Kind: MatchPath, depth: 4}, ProjectMatch{Kind: MatchPath, depth: 3}, true},
+		{"literal beats glob", ProjectMatch{Kind: MatchPath, depth: 3}, ProjectMatch{Kind: MatchPath, depth: 3, Glob: true}, true},
+		{"glob loses to literal", ProjectMatch{Kind: MatchPath, depth: 3, Glob: true}, ProjectMatch{Kind: MatchPath, depth: 3}, false},
+		{"equal", ProjectMatch{Kind: MatchPath, depth: 3}, ProjectMatch{Kind: MatchPath, depth: 3}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moreSpecific(tt.a, tt.b); got != tt.want {
+				t.Errorf("moreSpecific = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupProject(t *testing.T) {
+	home := normalizePath(t.TempDir())
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	api := filepath.Join(home, "work", "api")
+	if err := os.MkdirAll(api, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	projects := filepath.Join(home, ".config", "one", "projects")
+	writeFile(t, filepath.Join(projects, "work.yml"), "project:\n  name: work\n  paths: [~/work/*]\n")
+	writeFile(t, filepath.Join(projects, "api.yml"), "project:\n  name: api\n  paths: [~/work/api]\n")
+	writeFile(t, filepath.Join(projects, "other.yml"), "project:\n  name: other\n  paths: [~/other]\n")
+	writeFile(t, filepath.Join(projects, "broken.yml"), "project: [\n")
+
+	lookup, err := LookupProject(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, match := range lookup.Matches {
+		names = append(names, match.Name)
+	}
+	if want := []string{"api", "work"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("matches = %v, want %v", names, want)
+	}
+	if want := []string{"broken.yml"}; !reflect.DeepEqual(lookup.Invalid, want) {
+		t.Errorf("invalid = %v, want %v", lookup.Invalid, want)
+	}
+}
+
+func TestDirectories(t *testing.T) {
+	home := normalizePath(t.TempDir())
+	t.Setenv("HOME", home)
+
+	for _, dir := range []string{"work/api", "work/web"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(home, "work", "notes.txt"), "")
+
+	cfg := &ProjectConfig{Project: ProjectInfo{Paths: []string{"~/work/*", "~/work/api", "~/missing"}}}
+	want := []string{filepath.Join(home, "work", "api"), filepath.Join(home, "work", "web")}
+	if got := cfg.Directories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Directories() = %v, want %v", got, want)
+	}
+}
@@ -10,22 +10,43 @@ type ProjectConfig struct {
 	Templates      *Templates      `yaml:"templates,omitempty"`
 	BranchPatterns *BranchPatterns `yaml:"branch_patterns,omitempty"`
 	Hooks          *Hooks          `yaml:"hooks,omitempty"`
+
+	// sources records which layer set each value, see Sources
+	sources map[string]string
 }
 
 // ProjectInfo contains basic project information
 type ProjectInfo struct {
 	Name  string   `yaml:"name"`
 	Paths []string `yaml:"paths"`
+	// Remotes match repositories by remote URL instead of location,
+	// e.g. github.com/acme/api or github.com/acme/*
+	Remotes []string `yaml:"remotes,omitempty"`
 }
 
 // GitConfig contains git-related configuration
 type GitConfig struct {
-	Provider   string           `yaml:"provider"`
-	Remote     string           `yaml:"remote"`
-	BaseBranch string           `yaml:"base_branch"`
-	GitHub     *GitHubConfig    `yaml:"github,omitempty"`
-	GitLab     *GitLabConfig    `yaml:"gitlab,omitempty"`
-	Bitbucket  *BitbucketConfig `yaml:"bitbucket,omitempty"`
+	Provider     string           `yaml:"provider"`
+	Remote       string           `yaml:"remote"`
+	BaseBranch   string           `yaml:"base_branch"`
+	DefaultDraft bool             `yaml:"default_draft,omitempty"`
+	PR           *PRDefaults      `yaml:"pr,omitempty"`
+	GitHub       *GitHubConfig    `yaml:"github,omitempty"`
+	GitLab       *GitLabConfig    `yaml:"gitlab,omitempty"`
+	Bitbucket    *BitbucketConfig `yaml:"bitbucket,omitempty"`
+}
+
+// PRDefaults are added to every new pull/merge request, on top of the
+// --reviewer, --assignee and --label flags
+type PRDefaults struct {
+	Reviewers []string `yaml:"reviewers,omitempty"`
+	Assignees []string `yaml:"assignees,omitempty"`
+	Labels    []string `yaml:"labels,omitempty"`
+	Milestone string   `yaml:"milestone,omitempty"`
+	// CodeOwners requests reviews from the CODEOWNERS of the changed files
+	CodeOwners bool `yaml:"codeowners,omitempty"`
+	// MergeMethod is the default for one pr merge: merge, squash or rebase
+	MergeMethod string `yaml:"merge_method,omitempty"`
 }
 
 // GitHubConfig contains GitHub-specific settings
@@ -33,12 +54,28 @@ type GitHubConfig struct {
 	Owner    string `yaml:"owner"`
 	Repo     string `yaml:"repo"`
 	TokenEnv string `yaml:"token_env"`
+
+	// BaseURL is the API root for GitHub Enterprise Server,
+	// e.g. https://git.acme.internal/api/v3
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Hosts are additional remote hostnames served by this GitHub instance
+	Hosts []string `yaml:"hosts,omitempty"`
+	// CACert is a PEM bundle to trust for instances on a private PKI
+	CACert string `yaml:"ca_cert,omitempty"`
 }
 
 // GitLabConfig contains GitLab-specific settings
 type GitLabConfig struct {
 	ProjectID int    `yaml:"project_id"`
 	TokenEnv  string `yaml:"token_env"`
+
+	// BaseURL is the API root for self-managed GitLab,
+	// e.g. https://git.acme.internal/api/v4
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Hosts are additional remote hostnames served by this GitLab instance
+	Hosts []string `yaml:"hosts,omitempty"`
+	// CACert is a PEM bundle to trust for instances on a private PKI
+	CACert string `yaml:"ca_cert,omitempty"`
 }
 
 // BitbucketConfig contains Bitbucket-specific settings
@@ -56,21 +93,80 @@ type BrowserConfig struct {
 
 // TicketConfig contains ticket system configuration
 type TicketConfig struct {
-	System  string      `yaml:"system"`
-	BaseURL string      `yaml:"base_url"`
-	Jira    *JiraConfig `yaml:"jira,omitempty"`
+	System  string              `yaml:"system"`
+	BaseURL string              `yaml:"base_url"`
+	Jira    *JiraConfig         `yaml:"jira,omitempty"`
+	Linear  *LinearConfig       `yaml:"linear,omitempty"`
+	GitHub  *GitHubIssuesConfig `yaml:"github,omitempty"`
 }
 
 // JiraConfig contains Jira-specific settings
 type JiraConfig struct {
 	BoardID  string `yaml:"board_id"`
 	TokenEnv string `yaml:"token_env,omitempty"`
+	// Auth is cloud (email and API token, the default), pat (Data Center
+	// personal access token) or oauth (OAuth 2.0 3LO)
+	Auth string `yaml:"auth,omitempty"`
+	// Email is the Atlassian account of a cloud API token, so token_env
+	// can hold the plain token
+	Email string `yaml:"email,omitempty"`
+	// APIVersion is the REST API version, 2 (default) or 3
+	APIVersion int              `yaml:"api_version,omitempty"`
+	OAuth      *JiraOAuthConfig `yaml:"oauth,omitempty"`
+	Workflow   *JiraWorkflow    `yaml:"workflow,omitempty"`
+}
+
+// JiraOAuthConfig identifies the OAuth 2.0 (3LO) app registered in the
+// Atlassian developer console
+type JiraOAuthConfig struct {
+	ClientID        string `yaml:"client_id"`
+	ClientSecretEnv string `yaml:"client_secret_env"`
+	// CallbackPort is the localhost port of the app's callback URL,
+	// http://localhost:<port>/callback (default 8735)
+	CallbackPort int `yaml:"callback_port,omitempty"`
+}
+
+// JiraWorkflow updates the issue as work moves through one's commands
+type JiraWorkflow struct {
+	// Start runs when one start creates the branch for the issue
+	Start *JiraStep `yaml:"start,omitempty"`
+	// PR runs when one pr opens a pull request for the issue
+	PR *JiraStep `yaml:"pr,omitempty"`
+}
+
+// JiraStep lists what to do with an issue at a workflow step
+type JiraStep struct {
+	// Transition is the name of a transition, or of the status it leads
+	// to, e.g. "In Progress"
+	Transition string `yaml:"transition,omitempty"`
+	// Assign assigns the issue to the authenticated user
+	Assign bool `yaml:"assign,omitempty"`
+	// Comment is posted on the issue, rendered like the PR templates
+	Comment string `yaml:"comment,omitempty"`
+	// LinkPR adds the pull request to the issue as a remote link
+	LinkPR bool `yaml:"link_pr,omitempty"`
+}
+
+// LinearConfig contains Linear-specific settings
+type LinearConfig struct {
+	TokenEnv string `yaml:"token_env,omitempty"`
+}
+
+// GitHubIssuesConfig contains GitHub Issues settings. Owner and repo default
+// to the git.github block when issues live in the same repository.
+type GitHubIssuesConfig struct {
+	Owner    string `yaml:"owner,omitempty"`
+	Repo     string `yaml:"repo,omitempty"`
+	TokenEnv string `yaml:"token_env,omitempty"`
 }
 
 // Templates contains PR/MR template strings
 type Templates struct {
 	PRTitle string `yaml:"pr_title"`
 	PRBody  string `yaml:"pr_body"`
+	// PRBodyFile is a body template file, relative to the repository root
+	// unless absolute
+	PRBodyFile string `yaml:"pr_body_file,omitempty"`
 }
 
 // BranchPatterns contains regex patterns for branch parsing
@@ -80,8 +176,9 @@ type BranchPatterns struct {
 
 // Hooks contains commands to run at specific points
 type Hooks struct {
-	BeforePR []Hook `yaml:"before_pr,omitempty"`
-	AfterPR  []Hook `yaml:"after_pr,omitempty"`
+	BeforePR   []Hook `yaml:"before_pr,omitempty"`
+	AfterPR    []Hook `yaml:"after_pr,omitempty"`
+	AfterMerge []Hook `yaml:"after_merge,omitempty"`
 }
 
 // Hook represents a command to run
@@ -0,0 +1,468 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"one/internal/template"
+)
+
+// Severity levels for diagnostics
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is a problem found in a configuration file
+type Diagnostic struct {
+	File     string
+	Line     int
+	Severity string
+	Message  string
+}
+
+// String formats the diagnostic as file:line: severity: message
+func (d Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, d.Severity, d.Message)
+}
+
+// ConfigFile is a project configuration file together with its YAML tree,
+// which is kept to point diagnostics at line numbers
+type ConfigFile struct {
+	Path   string
+	Config *ProjectConfig
+	root   *yaml.Node
+}
+
+// Line returns the line of the key at the given path, e.g.
+// Line("git", "github", "owner") or Line("hooks", "before_pr", "0"). When the
+// key is missing it returns the line of its nearest existing parent.
+func (f *ConfigFile) Line(path ...string) int {
+	if f.root == nil || len(f.root.Content) == 0 {
+		return 0
+	}
+
+	node := f.root.Content[0]
+	line := node.Line
+	for _, key := range path {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			return line
+		}
+		node = next
+	}
+
+	return line
+}
+
+// Error adds an error diagnostic for the key at path
+func (f *ConfigFile) Error(diags []Diagnostic, message string, path ...string) []Diagnostic {
+	return append(diags, Diagnostic{File: f.Path, Line: f.Line(path...), Severity: SeverityError, Message: message})
+}
+
+// Warning adds a warning diagnostic for the key at path
+func (f *ConfigFile) Warning(diags []Diagnostic, message string, path ...string) []Diagnostic {
+	return append(diags, Diagnostic{File: f.Path, Line: f.Line(path...), Severity: SeverityWarning, Message: message})
+}
+
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// ValidateProjects checks every file in the projects directory, including
+// checks across projects such as overlapping paths. Files that could not be
+// parsed at all are reported but not returned.
+func ValidateProjects() ([]*ConfigFile, []Diagnostic, error) {
+	projectsDir, err := GetProjectsDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read projects directory: %w", err)
+	}
+
+	var files []*ConfigFile
+	var diags []Diagnostic
+	if globalPath, err := GetGlobalConfigPath(); err == nil {
+		if _, err := os.Stat(globalPath); err == nil {
+			diags = append(diags, validateGlobalFile(globalPath)...)
+		}
+	}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		file, fileDiags := ValidateFile(filepath.Join(projectsDir, entry.Name()))
+		diags = append(diags, fileDiags...)
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+
+	diags = append(diags, checkOverlappingPaths(files)...)
+
+	if dir, err := os.Getwd(); err == nil {
+		if repoFile := findRepoConfig(dir); repoFile != "" {
+			diags = append(diags, ValidateRepoFile(repoFile)...)
+		}
+	}
+
+	return files, diags, nil
+}
+
+// ValidateRepoFile checks that an in-repo .one.yml only sets the keys a
+// repository may override
+func ValidateRepoFile(path string) []Diagnostic {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []Diagnostic{{File: path, Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return yamlDiagnostics(path, err)
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	values := map[string]interface{}{}
+	if err := root.Decode(&values); err != nil {
+		return yamlDiagnostics(path, err)
+	}
+
+	file := &ConfigFile{Path: path, root: &root}
+	_, rejected := splitRepoValues(values, "")
+
+	var diags []Diagnostic
+	for _, key := range rejected {
+		message := fmt.Sprintf("%s cannot be set in %s (allowed: %s)", key, RepoConfigFile, strings.Join(repoConfigKeys, ", "))
+		diags = file.Error(diags, message, strings.Split(key, ".")...)
+	}
+	if err := checkRepoBodyFile(values, path); err != nil {
+		diags = file.Error(diags, err.Error(), "templates", "pr_body_file")
+	}
+	return diags
+}
+
+// ValidateFile checks a single project configuration file. The returned file
+// is nil when the YAML could not be parsed.
+func ValidateFile(path string) (*ConfigFile, []Diagnostic) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, []Diagnostic{{File: path, Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlDiagnostics(path, err)
+	}
+
+	file := &ConfigFile{Path: path, Config: &ProjectConfig{}, root: &root}
+
+	// Decode again with strict field checking to catch unknown keys
+	var diags []Diagnostic
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file.Config); err != nil && !errors.Is(err, io.EOF) {
+		diags = append(diags, yamlDiagnostics(path, err)...)
+	}
+
+	diags = append(diags, file.check()...)
+	return file, diags
+}
+
+// validateGlobalFile checks the global configuration file for syntax errors
+// and unknown keys
+func validateGlobalFile(path string) []Diagnostic {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []Diagnostic{{File: path, Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var global GlobalConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&global); err != nil && !errors.Is(err, io.EOF) {
+		return yamlDiagnostics(path, err)
+	}
+	return nil
+}
+
+// yamlDiagnostics turns YAML parse and decode errors into diagnostics
+func yamlDiagnostics(path string, err error) []Diagnostic {
+	var messages []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	var diags []Diagnostic
+	for _, message := range messages {
+		diag := Diagnostic{File: path, Severity: SeverityError, Message: message}
+		if m := yamlLinePattern.FindStringSubmatch(message); m != nil {
+			diag.Line, _ = strconv.Atoi(m[1])
+			diag.Message = m[2]
+		}
+		if strings.HasPrefix(diag.Message, "field ") && strings.Contains(diag.Message, " not found in type ") {
+			field := strings.Fields(diag.Message)[1]
+			diag.Message = fmt.Sprintf("unknown key %q", field)
+		}
+		diags = append(diags, diag)
+	}
+	return diags
+}
+
+// check runs the semantic checks of a single file
+func (f *ConfigFile) check() []Diagnostic {
+	var diags []Diagnostic
+	cfg := f.Config
+
+	if cfg.Project.Name == "" {
+		diags = f.Error(diags, "project.name is required", "project", "name")
+	}
+	if len(cfg.Project.Paths) == 0 && len(cfg.Project.Remotes) == 0 {
+		diags = f.Error(diags, "project.paths or project.remotes must list at least one entry", "project", "paths")
+	}
+	for i, path := range cfg.Project.Paths {
+		if isGlob(path) {
+			if _, err := filepath.Match(path, ""); err != nil {
+				diags = f.Error(diags, fmt.Sprintf("invalid path pattern %s: %v", path, err), "project", "paths", strconv.Itoa(i))
+			} else if matches, _ := filepath.Glob(expandHome(path)); len(matches) == 0 {
+				diags = f.Warning(diags, fmt.Sprintf("path pattern %s matches no directories", path), "project", "paths", strconv.Itoa(i))
+			}
+			continue
+		}
+		if _, err := os.Stat(expandHome(path)); err != nil {
+			diags = f.Warning(diags, fmt.Sprintf("path %s does not exist", path), "project", "paths", strconv.Itoa(i))
+		}
+	}
+
+	diags = append(diags, f.checkGit()...)
+	diags = append(diags, f.checkTicket()...)
+
+	if bp := cfg.BranchPatterns; bp != nil && bp.TicketID != "" {
+		re, err := regexp.Compile(bp.TicketID)
+		if err != nil {
+			diags = f.Error(diags, fmt.Sprintf("invalid ticket_id pattern: %v", err), "branch_patterns", "ticket_id")
+		} else if re.NumSubexp() == 0 {
+			diags = f.Error(diags, "ticket_id pattern needs a capture group around the ticket ID", "branch_patterns", "ticket_id")
+		}
+	}
+
+	if t := cfg.Templates; t != nil {
+		diags = append(diags, f.checkTemplate(t.PRTitle, "templates", "pr_title")...)
+		diags = append(diags, f.checkTemplate(t.PRBody, "templates", "pr_body")...)
+		if t.PRBodyFile != "" && filepath.IsAbs(expandHome(t.PRBodyFile)) {
+			if _, err := os.Stat(expandHome(t.PRBodyFile)); err != nil {
+				diags = f.Error(diags, fmt.Sprintf("pr_body_file %s does not exist", t.PRBodyFile), "templates", "pr_body_file")
+			}
+		}
+	}
+
+	return diags
+}
+
+// checkGit checks the git provider and its settings block
+func (f *ConfigFile) checkGit() []Diagnostic {
+	var diags []Diagnostic
+	git := f.Config.Git
+
+	switch git.Provider {
+	case "github":
+		if git.GitHub == nil {
+			return f.Error(diags, "git.github is required when provider is github", "git")
+		}
+		if git.GitHub.Owner == "" || git.GitHub.Repo == "" {
+			diags = f.Error(diags, "git.github.owner and git.github.repo are required", "git", "github")
+		}
+	case "gitlab":
+		if git.GitLab == nil {
+			return f.Error(diags, "git.gitlab is required when provider is gitlab", "git")
+		}
+		if git.GitLab.ProjectID == 0 {
+			diags = f.Error(diags, "git.gitlab.project_id is required", "git", "gitlab")
+		}
+	case "bitbucket":
+		if git.Bitbucket == nil {
+			return f.Error(diags, "git.bitbucket is required when provider is bitbucket", "git")
+		}
+		if git.Bitbucket.Workspace == "" || git.Bitbucket.RepoSlug == "" {
+			diags = f.Error(diags, "git.bitbucket.workspace and git.bitbucket.repo_slug are required", "git", "bitbucket")
+		}
+	case "":
+		diags = f.Error(diags, "git.provider is required", "git", "provider")
+	default:
+		diags = f.Error(diags, fmt.Sprintf("unsupported git provider %q (use github, gitlab or bitbucket)", git.Provider), "git", "provider")
+	}
+
+	if git.PR != nil {
+		switch git.PR.MergeMethod {
+		case "", "merge", "squash", "rebase":
+		default:
+			diags = f.Error(diags, fmt.Sprintf("unsupported merge method %q (use merge, squash or rebase)", git.PR.MergeMethod), "git", "pr", "merge_method")
+		}
+	}
+
+	return diags
+}
+
+// checkTicket checks the ticket system and its settings block
+func (f *ConfigFile) checkTicket() []Diagnostic {
+	var diags []Diagnostic
+	ticket := f.Config.Ticket
+	if ticket == nil {
+		return nil
+	}
+
+	switch ticket.System {
+	case "jira":
+		if ticket.BaseURL == "" {
+			diags = f.Error(diags, "ticket.base_url is required for Jira", "ticket", "base_url")
+		}
+		if jira := ticket.Jira; jira != nil {
+			diags = append(diags, f.checkJiraAuth(jira)...)
+		}
+		if ticket.Jira != nil && ticket.Jira.Workflow != nil {
+			workflow := ticket.Jira.Workflow
+			if workflow.Start != nil {
+				diags = append(diags, f.checkTemplate(workflow.Start.Comment, "ticket", "jira", "workflow", "start", "comment")...)
+				if workflow.Start.LinkPR {
+					diags = f.Warning(diags, "link_pr has no effect on the start step, there is no PR yet", "ticket", "jira", "workflow", "start", "link_pr")
+				}
+			}
+			if workflow.PR != nil {
+				diags = append(diags, f.checkTemplate(workflow.PR.Comment, "ticket", "jira", "workflow", "pr", "comment")...)
+			}
+		}
+	case "linear":
+	case "github":
+		owner, repo := "", ""
+		if f.Config.Git.GitHub != nil {
+			owner, repo = f.Config.Git.GitHub.Owner, f.Config.Git.GitHub.Repo
+		}
+		if gh := ticket.GitHub; gh != nil {
+			if gh.Owner != "" {
+				owner = gh.Owner
+			}
+			if gh.Repo != "" {
+				repo = gh.Repo
+			}
+		}
+		if owner == "" || repo == "" {
+			diags = f.Error(diags, "ticket.github.owner and ticket.github.repo are required unless git.github is set", "ticket")
+		}
+	case "":
+		diags = f.Error(diags, "ticket.system is required", "ticket", "system")
+	default:
+		diags = f.Error(diags, fmt.Sprintf("unsupported ticket system %q (use jira, linear or github)", ticket.System), "ticket", "system")
+	}
+
+	return diags
+}
+
+// checkJiraAuth checks the Jira authentication mode and API version
+func (f *ConfigFile) checkJiraAuth(jira *JiraConfig) []Diagnostic {
+	var diags []Diagnostic
+
+	switch jira.Auth {
+	case "", "cloud":
+	case "pat":
+		if jira.APIVersion == 3 {
+			diags = f.Error(diags, "Jira Data Center only supports api_version 2", "ticket", "jira", "api_version")
+		}
+	case "oauth":
+		if jira.OAuth == nil || jira.OAuth.ClientID == "" || jira.OAuth.ClientSecretEnv == "" {
+			diags = f.Error(diags, "ticket.jira.oauth.client_id and client_secret_env are required for oauth", "ticket", "jira", "auth")
+		}
+	default:
+		diags = f.Error(diags, fmt.Sprintf("unsupported Jira auth %q (use cloud, pat or oauth)", jira.Auth), "ticket", "jira", "auth")
+	}
+
+	switch jira.APIVersion {
+	case 0, 2, 3:
+	default:
+		diags = f.Error(diags, fmt.Sprintf("unsupported Jira api_version %d (use 2 or 3)", jira.APIVersion), "ticket", "jira", "api_version")
+	}
+
+	return diags
+}
+
+// checkTemplate reports template syntax errors and {placeholders} that are
+// not template variables
+func (f *ConfigFile) checkTemplate(text string, path ...string) []Diagnostic {
+	unknown, err := template.Check(text)
+	if err != nil {
+		return f.Error(nil, err.Error(), path...)
+	}
+
+	var diags []Diagnostic
+	for _, name := range unknown {
+		diags = f.Warning(diags, fmt.Sprintf("unknown template placeholder {%s}", name), path...)
+	}
+	return diags
+}
+
+// checkOverlappingPaths reports project paths claimed by several projects,
+// and paths nested inside another project's path, where the most specific
+// path wins
+func checkOverlappingPaths(files []*ConfigFile) []Diagnostic {
+	var diags []Diagnostic
+	for i, a := range files {
+		for j, b := range files {
+			if i == j {
+				continue
+			}
+			for pi, pathA := range a.Config.Project.Paths {
+				for _, pathB := range b.Config.Project.Paths {
+					normA, normB := normalizePath(expandHome(pathA)), normalizePath(expandHome(pathB))
+					switch {
+					case normA == normB && i < j:
+						diags = a.Error(diags, fmt.Sprintf("path %s is also claimed by %s", pathA, filepath.Base(b.Path)),
+							"project", "paths", strconv.Itoa(pi))
+					case strings.HasPrefix(normA, normB+string(filepath.Separator)):
+						diags = a.Warning(diags, fmt.Sprintf("path %s is nested inside %s of %s and takes precedence there", pathA, pathB, filepath.Base(b.Path)),
+							"project", "paths", strconv.Itoa(pi))
+					}
+				}
+			}
+		}
+	}
+	return diags
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// codeOwnersFiles are the places GitHub and GitLab look for CODEOWNERS, in
+// lookup order
+var codeOwnersFiles = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// codeOwnersRule is one line of a CODEOWNERS file
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// CodeOwners returns the owners of the given files according to the
+// repository's CODEOWNERS file, without the leading @. As on GitHub, the
+// last matching rule wins. Email owners are left out since they cannot be
+// requested as reviewers by name.
+func CodeOwners(root string, files []string) ([]string, error) {
+	rules, err := readCodeOwners(root)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var owners []string
+	for _, file := range files {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !rules[i].pattern.MatchString(file) {
+				continue
+			}
+			for _, owner := range rules[i].owners {
+				if !seen[owner] {
+					seen[owner] = true
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+
+	sort.Strings(owners)
+	return owners, nil
+}
+
+// readCodeOwners parses the first CODEOWNERS file found in the repository
+func readCodeOwners(root string) ([]codeOwnersRule, error) {
+	for _, name := range codeOwnersFiles {
+		file, err := os.Open(filepath.Join(root, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		var rules []codeOwnersRule
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if i := strings.Index(line, "#"); i >= 0 {
+				line = line[:i]
+			}
+			fields := strings.Fields(line)
+
+			// GitLab section headers look like [Section] or ^[Section]
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+				continue
+			}
+
+			rule := codeOwnersRule{pattern: codeOwnersPattern(fields[0])}
+			for _, owner := range fields[1:] {
+				if strings.HasPrefix(owner, "@") && !strings.HasPrefix(owner, "@@") {
+					rule.owners = append(rule.owners, strings.TrimPrefix(owner, "@"))
+				}
+			}
+			rules = append(rules, rule)
+		}
+		return rules, scanner.Err()
+	}
+	return nil, nil
+}
+
+// codeOwnersPattern converts a gitignore-style CODEOWNERS pattern into a
+// regular expression over slash-separated paths. Patterns with a leading or
+// inner slash are anchored to the root; a pattern naming a directory also
+// matches everything below it.
+func codeOwnersPattern(pattern string) *regexp.Regexp {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	prefix := "^(?:.*/)?"
+	if anchored {
+		prefix = "^"
+	}
+	return regexp.MustCompile(prefix + expr.String() + "(?:/.*)?$")
+}
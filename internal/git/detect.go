@@ -12,15 +12,19 @@ import (
 // RemoteInfo contains parsed information about a Git remote
 type RemoteInfo struct {
 	Provider  string // github, gitlab, bitbucket
-	Owner     string // organization or username
+	Host      string // hostname of the remote
+	Owner     string // organization or username (GitLab: full group path)
 	Repo      string // repository name
 	URL       string // full remote URL
 	ProjectID int    // for GitLab (0 if not applicable)
 }
 
-// DetectRemote attempts to detect and parse the Git remote information
-func DetectRemote(remoteName string) (*RemoteInfo, error) {
-	repo, err := git.PlainOpen(".")
+// DetectRemote attempts to detect and parse the Git remote information.
+// hosts maps self-hosted hostnames to providers and may be nil.
+func DetectRemote(remoteName string, hosts map[string]string) (*RemoteInfo, error) {
+	repo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("not a git repository: %w", err)
 	}
@@ -35,55 +39,61 @@ func DetectRemote(remoteName string) (*RemoteInfo, error) {
 	}
 
 	remoteURL := remote.Config().URLs[0]
-	return ParseRemoteURL(remoteURL)
+	return ParseRemoteURL(remoteURL, hosts)
 }
 
-// ParseRemoteURL parses a Git remote URL and extracts provider information
-func ParseRemoteURL(remoteURL string) (*RemoteInfo, error) {
+// scpLikePattern matches scp-style SSH remotes (git@github.com:owner/repo.git)
+var scpLikePattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+?)(?:\.git)?/?$`)
+
+// ParseRemoteURL parses a Git remote URL and extracts provider information.
+// hosts maps self-hosted hostnames (e.g. git.acme.internal) to a provider and
+// takes precedence over name-based detection; it may be nil.
+func ParseRemoteURL(remoteURL string, hosts map[string]string) (*RemoteInfo, error) {
 	info := &RemoteInfo{
 		URL: remoteURL,
 	}
 
-	// Handle SSH URLs (git@github.com:owner/repo.git)
-	sshPattern := regexp.MustCompile(`^(?:ssh://)?git@([^:]+):(.+?)(?:\.git)?$`)
-	if matches := sshPattern.FindStringSubmatch(remoteURL); matches != nil {
-		host := matches[1]
-		path := matches[2]
-
-		info.Provider = detectProvider(host)
-		parts := strings.Split(path, "/")
-		if len(parts) >= 2 {
-			info.Owner = parts[0]
-			info.Repo = parts[1]
+	var host, path string
+	if strings.Contains(remoteURL, "://") {
+		// HTTPS and ssh:// URLs, possibly with a port
+		parsedURL, err := url.Parse(remoteURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse remote URL: %w", err)
 		}
-		return info, nil
-	}
-
-	// Handle HTTPS URLs
-	parsedURL, err := url.Parse(remoteURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse remote URL: %w", err)
+		host = parsedURL.Hostname()
+		path = parsedURL.Path
+	} else if matches := scpLikePattern.FindStringSubmatch(remoteURL); matches != nil {
+		host = matches[1]
+		path = matches[2]
+	} else {
+		return nil, fmt.Errorf("failed to parse remote URL: %s", remoteURL)
 	}
 
-	info.Provider = detectProvider(parsedURL.Host)
+	info.Host = strings.ToLower(host)
+	info.Provider = detectProvider(info.Host, hosts)
 
-	// Extract owner and repo from path
-	path := strings.TrimPrefix(parsedURL.Path, "/")
+	// Extract owner and repo from path. GitLab nests projects in subgroups,
+	// so everything before the last segment is the owner.
+	path = strings.Trim(path, "/")
 	path = strings.TrimSuffix(path, ".git")
 	parts := strings.Split(path, "/")
 
 	if len(parts) >= 2 {
-		info.Owner = parts[0]
-		info.Repo = parts[1]
+		info.Owner = strings.Join(parts[:len(parts)-1], "/")
+		info.Repo = parts[len(parts)-1]
 	}
 
 	return info, nil
 }
 
 // detectProvider determines the Git provider from the hostname
-func detectProvider(host string) string {
+func detectProvider(host string, hosts map[string]string) string {
 	host = strings.ToLower(host)
 
+	if provider, ok := hosts[host]; ok {
+		return provider
+	}
+
 	if strings.Contains(host, "github") {
 		return "github"
 	}
@@ -97,6 +107,16 @@ func detectProvider(host string) string {
 	return "unknown"
 }
 
+// IsPublicHost reports whether host is one of the hosted SaaS providers
+func IsPublicHost(host string) bool {
+	switch strings.ToLower(host) {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		return true
+	default:
+		return false
+	}
+}
+
 // GetDefaultBranch attempts to detect the default branch
 func GetDefaultBranch() (string, error) {
 	repo, err := git.PlainOpen(".")
@@ -120,3 +140,34 @@ func GetDefaultBranch() (string, error) {
 	// Default to main
 	return "main", nil
 }
+
+// RemoteURLs returns the URLs of every remote of the repository containing dir
+func RemoteURLs(dir string) ([]string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	var urls []string
+	for _, remote := range remotes {
+		urls = append(urls, remote.Config().URLs...)
+	}
+	return urls, nil
+}
+
+// RemoteKey reduces a remote URL to host/owner/repo in lower case, so the
+// HTTPS and SSH URLs of a repository compare equal
+func RemoteKey(remoteURL string) string {
+	info, err := ParseRemoteURL(remoteURL, nil)
+	if err != nil || info.Owner == "" {
+		return strings.ToLower(remoteURL)
+	}
+	return strings.ToLower(info.Host + "/" + info.Owner + "/" + info.Repo)
+}
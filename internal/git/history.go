@@ -0,0 +1,238 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit is a commit on the current branch
+type Commit struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Email     string
+	Subject   string
+	Body      string
+}
+
+// FileChange is a file changed on the current branch
+type FileChange struct {
+	Path      string
+	Status    string // added, modified, deleted, renamed, ...
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+// DiffStats summarises the changes on the current branch
+type DiffStats struct {
+	Files     int
+	Additions int
+	Deletions int
+}
+
+// BranchChanges describes what the current branch adds on top of its base
+type BranchChanges struct {
+	Base    string // the ref the branch was compared with
+	Commits []Commit
+	Files   []FileChange
+	Stats   DiffStats
+}
+
+// Root returns the top-level directory of the worktree
+func (r *Repository) Root() (string, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+// ChangesSince lists the commits and file changes of HEAD that are not on
+// the base branch. The remote-tracking branch is preferred over the local
+// one, which may be stale. Merge commits are left out of the commit list.
+func (r *Repository) ChangesSince(remoteName, baseBranch string) (*BranchChanges, error) {
+	baseName := remoteName + "/" + baseBranch
+	baseRef, err := r.repo.Reference(plumbing.NewRemoteReferenceName(remoteName, baseBranch), true)
+	if err != nil {
+		baseName = baseBranch
+		baseRef, err = r.repo.Reference(plumbing.NewBranchReferenceName(baseBranch), true)
+		if err != nil {
+			return nil, fmt.Errorf("base branch %s not found: %w", baseBranch, err)
+		}
+	}
+
+	headRef, err := r.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	head, err := r.repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	base, err := r.repo.CommitObject(baseRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", baseName, err)
+	}
+
+	changes := &BranchChanges{Base: baseName}
+
+	// Everything reachable from the merge base is already on the base branch
+	mergeBases, err := head.MergeBase(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the merge base with %s: %w", baseName, err)
+	}
+
+	commits, err := branchCommits(head, mergeBases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+	for _, commit := range commits {
+		changes.Commits = append(changes.Commits, newCommit(commit))
+	}
+
+	// Unrelated histories have nothing to compare with
+	if len(mergeBases) == 0 {
+		return changes, nil
+	}
+
+	patch, err := mergeBases[0].Patch(head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", baseName, err)
+	}
+
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+
+		file := FileChange{Status: "modified", Binary: filePatch.IsBinary()}
+		switch {
+		case from == nil:
+			file.Path, file.Status = to.Path(), "added"
+		case to == nil:
+			file.Path, file.Status = from.Path(), "deleted"
+		default:
+			file.Path = to.Path()
+			if from.Path() != to.Path() {
+				file.Status = "renamed"
+			}
+		}
+
+		for _, chunk := range filePatch.Chunks() {
+			lines := strings.Count(chunk.Content(), "\n")
+			if !strings.HasSuffix(chunk.Content(), "\n") && chunk.Content() != "" {
+				lines++
+			}
+			switch chunk.Type() {
+			case diff.Add:
+				file.Additions += lines
+			case diff.Delete:
+				file.Deletions += lines
+			}
+		}
+
+		changes.Files = append(changes.Files, file)
+		changes.Stats.Files++
+		changes.Stats.Additions += file.Additions
+		changes.Stats.Deletions += file.Deletions
+	}
+
+	sort.Slice(changes.Files, func(i, j int) bool {
+		return changes.Files[i].Path < changes.Files[j].Path
+	})
+
+	return changes, nil
+}
+
+// branchCommits lists the commits reachable from head but not from any of the
+// merge bases, oldest first, leaving out merge commits
+func branchCommits(head *object.Commit, mergeBases []*object.Commit) ([]*object.Commit, error) {
+	stop := map[plumbing.Hash]bool{}
+	for _, mergeBase := range mergeBases {
+		stop[mergeBase.Hash] = true
+	}
+
+	// Without merges the first-parent chain runs straight into the merge base
+	var commits []*object.Commit
+	linear := true
+	for commit := head; !stop[commit.Hash]; {
+		if commit.NumParents() > 1 {
+			linear = false
+			break
+		}
+		commits = append(commits, commit)
+		if commit.NumParents() == 0 {
+			break
+		}
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		commit = parent
+	}
+
+	if linear {
+		for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+			commits[i], commits[j] = commits[j], commits[i]
+		}
+		return commits, nil
+	}
+
+	// A merge can reach the base branch's history through any parent, so
+	// exclude everything the merge bases can reach
+	excluded := map[plumbing.Hash]bool{}
+	for _, mergeBase := range mergeBases {
+		err := object.NewCommitPreorderIter(mergeBase, excluded, nil).ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits = nil
+	err := object.NewCommitPreorderIter(head, excluded, nil).ForEach(func(c *object.Commit) error {
+		if c.NumParents() <= 1 {
+			commits = append(commits, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.Before(commits[j].Committer.When)
+	})
+	return commits, nil
+}
+
+// newCommit converts a go-git commit
+func newCommit(c *object.Commit) Commit {
+	subject, body, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	return Commit{
+		Hash:      c.Hash.String(),
+		ShortHash: c.Hash.String()[:7],
+		Author:    c.Author.Name,
+		Email:     c.Author.Email,
+		Subject:   strings.TrimSpace(subject),
+		Body:      strings.TrimSpace(body),
+	}
+}
+
+// Author returns the configured git user name and email, from the
+// repository or the user's global git config
+func (r *Repository) Author() (string, string) {
+	cfg, err := r.repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return "", ""
+	}
+	return cfg.User.Name, cfg.User.Email
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// commitAt creates an empty commit with the given parents, committed at
+// offset seconds from testSignature
+func commitAt(t *testing.T, repo *Repository, message string, offset int, parents ...plumbing.Hash) plumbing.Hash {
+	t.Helper()
+	wt, err := repo.repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := *testSignature
+	sig.When = sig.When.Add(time.Duration(offset) * time.Second)
+	hash, err := wt.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: &sig, Parents: parents})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestChangesSinceCommits(t *testing.T) {
+	tests := []struct {
+		name string
+		// build creates the history on top of the initial commit and
+		// returns the tips of main and feature
+		build func(t *testing.T, repo *Repository, initial plumbing.Hash) (main, feature plumbing.Hash)
+		want  []string
+	}{
+		{
+			name: "linear branch",
+			build: func(t *testing.T, repo *Repository, initial plumbing.Hash) (plumbing.Hash, plumbing.Hash) {
+				b1 := commitAt(t, repo, "base work", 10, initial)
+				f1 := commitAt(t, repo, "first", 20, initial)
+				f2 := commitAt(t, repo, "second", 30, f1)
+				return b1, f2
+			},
+			want: []string{"first", "second"},
+		},
+		{
+			name: "base commit with a skewed clock",
+			build: func(t *testing.T, repo *Repository, initial plumbing.Hash) (plumbing.Hash, plumbing.Hash) {
+				b1 := commitAt(t, repo, "base work", -100, initial)
+				f1 := commitAt(t, repo, "first", 10, initial)
+				return b1, f1
+			},
+			want: []string{"first"},
+		},
+		{
+			name: "base merged into the branch",
+			build: func(t *testing.T, repo *Repository, initial plumbing.Hash) (plumbing.Hash, plumbing.Hash) {
+				b1 := commitAt(t, repo, "base work", 10, initial)
+				f1 := commitAt(t, repo, "first", 20, initial)
+				m := commitAt(t, repo, "Merge main", 30, f1, b1)
+				f2 := commitAt(t, repo, "second", 40, m)
+				return b1, f2
+			},
+			want: []string{"first", "second"},
+		},
+		{
+			name: "fork point reached through a second parent",
+			build: func(t *testing.T, repo *Repository, initial plumbing.Hash) (plumbing.Hash, plumbing.Hash) {
+				s1 := commitAt(t, repo, "side work", 10, initial)
+				m := commitAt(t, repo, "Merge side", 20, initial, s1)
+				f1 := commitAt(t, repo, "first", 30, s1)
+				return m, f1
+			},
+			want: []string{"first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := initTestRepo(t)
+			head, err := repo.repo.Head()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			main, feature := tt.build(t, repo, head.Hash())
+			refs := []*plumbing.Reference{
+				plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), main),
+				plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), feature),
+				plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("feature")),
+			}
+			for _, ref := range refs {
+				if err := repo.repo.Storer.SetReference(ref); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			changes, err := repo.ChangesSince("origin", "main")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var subjects []string
+			for _, commit := range changes.Commits {
+				subjects = append(subjects, commit.Subject)
+			}
+			if !reflect.DeepEqual(subjects, tt.want) {
+				t.Errorf("commits = %v, want %v", subjects, tt.want)
+			}
+		})
+	}
+}
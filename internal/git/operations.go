@@ -1,9 +1,14 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
+	"os/exec"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -121,13 +126,227 @@ func (r *Repository) Push(remoteName, branchName string) error {
 		},
 	})
 
-	if err != nil {
+	// Nothing to push, e.g. when re-running one pr without new commits
+	if err != nil && err != git.NoErrAlreadyUpToDate {
 		return fmt.Errorf("failed to push: %w", err)
 	}
 
 	return nil
 }
 
+// DeleteBranch deletes a local branch and its tracking configuration
+func (r *Repository) DeleteBranch(branchName string) error {
+	if err := r.repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(branchName)); err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+
+	if err := r.repo.DeleteBranch(branchName); err != nil && err != git.ErrBranchNotFound {
+		return fmt.Errorf("failed to delete branch config: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRemoteBranch deletes a branch on the remote
+func (r *Repository) DeleteRemoteBranch(remoteName, branchName string) error {
+	err := r.repo.Push(&git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(":" + plumbing.NewBranchReferenceName(branchName).String()),
+		},
+	})
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to delete remote branch: %w", err)
+	}
+
+	return nil
+}
+
+// RemoteBranches lists the branches known for a remote, sorted by name
+func (r *Repository) RemoteBranches(remoteName string) ([]string, error) {
+	refs, err := r.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+	defer refs.Close()
+
+	prefix := remoteName + "/"
+	var branches []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name()
+		if !name.IsRemote() || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		if branch, ok := strings.CutPrefix(name.Short(), prefix); ok && branch != "HEAD" {
+			branches = append(branches, branch)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// BranchInfo describes a local branch and its latest commit
+type BranchInfo struct {
+	Name      string
+	Subject   string    // subject of the latest commit
+	Committed time.Time // when the latest commit was made
+}
+
+// RecentBranches returns up to limit local branches, most recently
+// committed first
+func (r *Repository) RecentBranches(limit int) ([]BranchInfo, error) {
+	output, err := r.runGit("for-each-ref", "--sort=-committerdate", fmt.Sprintf("--count=%d", limit),
+		"--format=%(refname:short)%1f%(contents:subject)%1f%(committerdate:unix)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []BranchInfo
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+
+		branch := BranchInfo{Name: fields[0], Subject: fields[1]}
+		if seconds, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			branch.Committed = time.Unix(seconds, 0)
+		}
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// StashEntry describes an entry of the stash list
+type StashEntry struct {
+	Ref      string    // e.g. stash@{0}
+	Branch   string    // branch the changes were stashed from
+	Message  string    // stash message
+	TicketID string    // ticket recorded by one, empty for foreign stashes
+	Created  time.Time // when the stash was created
+}
+
+// stashPrefix marks stashes created by one
+const stashPrefix = "one: "
+
+// StashLabel builds the stash message one records for a ticket
+func StashLabel(ticketID, branch string) string {
+	return fmt.Sprintf("%s%s (from %s)", stashPrefix, ticketID, branch)
+}
+
+// Stash saves tracked and untracked changes with the given message. go-git
+// has no stash support, so this shells out to the git CLI.
+func (r *Repository) Stash(message string) error {
+	if _, err := r.runGit("stash", "push", "--include-untracked", "--message", message); err != nil {
+		return fmt.Errorf("failed to stash changes: %w", err)
+	}
+	return nil
+}
+
+// ListStashes returns all stash entries, newest first
+func (r *Repository) ListStashes() ([]StashEntry, error) {
+	output, err := r.runGit("stash", "list", "--format=%gd%x1f%gs%x1f%ct")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	var entries []StashEntry
+	for _, line := range strings.Split(output, "\n") {
+		if entry, ok := parseStashEntry(line); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// parseStashEntry parses a line of git stash list with the fields ref,
+// reflog subject and commit time separated by \x1f
+func parseStashEntry(line string) (StashEntry, bool) {
+	fields := strings.Split(line, "\x1f")
+	if len(fields) != 3 {
+		return StashEntry{}, false
+	}
+
+	entry := StashEntry{Ref: fields[0], Message: fields[1]}
+
+	// The reflog subject reads "On <branch>: <message>"
+	if subject := strings.TrimPrefix(fields[1], "On "); subject != fields[1] {
+		if i := strings.Index(subject, ": "); i >= 0 {
+			entry.Branch = subject[:i]
+			entry.Message = subject[i+2:]
+		}
+	}
+
+	if strings.HasPrefix(entry.Message, stashPrefix) {
+		// A hand-made "one: " stash has no label
+		if label := strings.Fields(strings.TrimPrefix(entry.Message, stashPrefix)); len(label) > 0 {
+			entry.TicketID = label[0]
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+		entry.Created = time.Unix(seconds, 0)
+	}
+
+	return entry, true
+}
+
+// FindStash returns the newest stash with the given message
+func (r *Repository) FindStash(message string) (*StashEntry, error) {
+	entries, err := r.ListStashes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Message == message {
+			return &entry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no stash found with message %q", message)
+}
+
+// PopStash applies a stash entry to the worktree and drops it
+func (r *Repository) PopStash(ref string) error {
+	if _, err := r.runGit("stash", "pop", ref); err != nil {
+		return fmt.Errorf("failed to restore stash %s: %w", ref, err)
+	}
+	return nil
+}
+
+// runGit runs the git CLI in the repository root
+func (r *Repository) runGit(args ...string) (string, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = worktree.Filesystem.Root()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 // ParseTicketID extracts the ticket ID from a branch name using a regex pattern
 func ParseTicketID(branchName, pattern string) (string, error) {
 	if pattern == "" {
@@ -147,6 +366,47 @@ func ParseTicketID(branchName, pattern string) (string, error) {
 	return matches[1], nil
 }
 
+var (
+	// ticketKeyPattern matches Jira and Linear keys such as ABC-123 or
+	// ENG-42, optionally after a prefix such as feature/
+	ticketKeyPattern = regexp.MustCompile(`(?:^|/)([A-Za-z][A-Za-z0-9]*-\d+)(?:$|[-_/.])`)
+	// issueNumberPattern matches GitHub issue branches such as 123-fix-login,
+	// gh-123 or issue-123
+	issueNumberPattern = regexp.MustCompile(`(?i)(?:^|/)(?:gh-|issue-)?(\d+)(?:$|[-_/.])`)
+	// branchTypes are branch name prefixes that look like ticket keys in
+	// names such as hotfix-123 or release-1.2 but are not
+	branchTypes = map[string]bool{
+		"feature": true, "feat": true, "bugfix": true, "bug": true, "fix": true,
+		"hotfix": true, "release": true, "chore": true, "issue": true, "gh": true,
+	}
+)
+
+// GuessTicketID extracts a ticket ID from a branch name using common naming
+// conventions of the ticket system, for when no pattern is configured
+func GuessTicketID(branchName, system string) (string, error) {
+	if system != "github" {
+		// Resume after a skipped key so hotfix-123/ABC-9 still finds ABC-9
+		for rest := branchName; ; {
+			loc := ticketKeyPattern.FindStringSubmatchIndex(rest)
+			if loc == nil {
+				break
+			}
+			key := rest[loc[2]:loc[3]]
+			if prefix, _, _ := strings.Cut(key, "-"); !branchTypes[strings.ToLower(prefix)] {
+				return strings.ToUpper(key), nil
+			}
+			rest = rest[loc[3]:]
+		}
+	}
+	if system != "jira" && system != "linear" {
+		if matches := issueNumberPattern.FindStringSubmatch(branchName); matches != nil {
+			return matches[1], nil
+		}
+	}
+
+	return "", fmt.Errorf("no ticket ID found in branch name")
+}
+
 // SanitizeBranchName cleans a string to be safe for use as a branch name
 func SanitizeBranchName(text string) string {
 	// Convert to lowercase
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// testSignature is the author of commits made by tests
+var testSignature = &object.Signature{Name: "Test", Email: "test@example.com", When: time.Unix(1700000000, 0)}
+
+// initTestRepo creates a repository with one commit on main and a bare
+// origin remote, and changes into it
+func initTestRepo(t *testing.T) *Repository {
+	t.Helper()
+	dir := t.TempDir()
+
+	remote := filepath.Join(dir, "origin.git")
+	if _, err := git.PlainInit(remote, true); err != nil {
+		t.Fatal(err)
+	}
+
+	local := filepath.Join(dir, "local")
+	repo, err := git.PlainInitWithOptions(local, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: "refs/heads/main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}}); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Commit("initial", &git.CommitOptions{AllowEmptyCommits: true, Author: testSignature}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(local)
+	return &Repository{repo: repo}
+}
+
+func TestPushAlreadyUpToDate(t *testing.T) {
+	repo := initTestRepo(t)
+
+	if err := repo.Push("origin", "main"); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	// Re-running one pr without new commits pushes again
+	if err := repo.Push("origin", "main"); err != nil {
+		t.Fatalf("push without new commits: %v", err)
+	}
+}
+
+func TestGuessTicketID(t *testing.T) {
+	tests := []struct {
+		branch string
+		system string
+		want   string
+	}{
+		{"ABC-123-fix-login", "jira", "ABC-123"},
+		{"feature/ENG-42", "linear", "ENG-42"},
+		{"jdoe/eng-42-fix-login", "linear", "ENG-42"},
+		{"hotfix-123", "jira", ""},
+		{"release-1.2", "jira", ""},
+		{"bugfix/PROJ-7-crash", "jira", "PROJ-7"},
+		{"hotfix-123/ABC-9", "jira", "ABC-9"},
+		{"123-fix-login", "github", "123"},
+		{"gh-123", "github", "123"},
+		{"feature/issue-77", "github", "77"},
+		{"ABC-123-fix-login", "github", ""},
+		{"hotfix-123", "", ""},
+		{"gh-123", "", "123"},
+		{"fix-login", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system+" "+tt.branch, func(t *testing.T) {
+			got, err := GuessTicketID(tt.branch, tt.system)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("GuessTicketID(%q, %q) = %q, want no ticket ID", tt.branch, tt.system, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("GuessTicketID(%q, %q) = %q, %v, want %q", tt.branch, tt.system, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStashEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want StashEntry
+		ok   bool
+	}{
+		{
+			name: "stash made by one",
+			line: "stash@{0}\x1fOn main: one: PROJ-1 (from main)\x1f1700000000",
+			want: StashEntry{Ref: "stash@{0}", Branch: "main", Message: "one: PROJ-1 (from main)", TicketID: "PROJ-1", Created: time.Unix(1700000000, 0)},
+			ok:   true,
+		},
+		{
+			name: "empty label",
+			line: "stash@{1}\x1fOn main: one: \x1f1700000000",
+			want: StashEntry{Ref: "stash@{1}", Branch: "main", Message: "one: ", Created: time.Unix(1700000000, 0)},
+			ok:   true,
+		},
+		{
+			name: "foreign stash",
+			line: "stash@{2}\x1fWIP on feature: abc1234 fix\x1fnot a time",
+			want: StashEntry{Ref: "stash@{2}", Message: "WIP on feature: abc1234 fix"},
+			ok:   true,
+		},
+		{name: "empty line", line: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseStashEntry(tt.line)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStashEntry(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package template
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"one/internal/git"
+)
+
+// DefaultBody is the PR body used when no body template is configured
+const DefaultBody = `{{ with .Summary }}## Summary
+
+{{ . }}
+
+{{ end }}{{ if .Commits }}## Commits
+
+{{ range .Commits }}- {{ .Subject }}
+{{ end }}
+{{ end }}{{ if .Files }}## Changed Files
+
+{{ range .FilesByDirectory }}**{{ .Dir }}**
+{{ range .Files }}- ` + "`{{ .Name }}`" + ` ({{ .Change }})
+{{ end }}
+{{ end }}{{ .Stats.Files }} files changed, +{{ .Stats.Additions }} -{{ .Stats.Deletions }}
+{{ end }}`
+
+// conventionalPattern matches Conventional Commit subjects: type(scope)!: text
+var conventionalPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\([^)]*\))?!?: `)
+
+// commitTypes names the Conventional Commit types, in summary order
+var commitTypes = []struct {
+	Type     string
+	Singular string
+	Plural   string
+}{
+	{"feat", "feature", "features"},
+	{"fix", "fix", "fixes"},
+	{"perf", "performance improvement", "performance improvements"},
+	{"refactor", "refactor", "refactors"},
+	{"docs", "docs change", "docs changes"},
+	{"test", "test change", "test changes"},
+	{"build", "build change", "build changes"},
+	{"ci", "CI change", "CI changes"},
+	{"style", "style change", "style changes"},
+	{"chore", "chore", "chores"},
+	{"revert", "revert", "reverts"},
+}
+
+// CommitType returns the Conventional Commit type of a subject, or "" when
+// it does not follow the convention
+func CommitType(subject string) string {
+	if m := conventionalPattern.FindStringSubmatch(subject); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// Summary counts the commits by Conventional Commit type, e.g.
+// "2 features, 1 fix and 1 other commit"
+func (d *Data) Summary() string {
+	if len(d.Commits) == 0 {
+		return ""
+	}
+
+	counts := map[string]int{}
+	for _, commit := range d.Commits {
+		counts[CommitType(commit.Subject)]++
+	}
+
+	var parts []string
+	for _, t := range commitTypes {
+		switch n := counts[t.Type]; n {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+t.Singular)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %s", n, t.Plural))
+		}
+		delete(counts, t.Type)
+	}
+
+	// Unknown types and non-conventional subjects
+	other := 0
+	for _, n := range counts {
+		other += n
+	}
+	if other == 1 {
+		parts = append(parts, "1 other commit")
+	} else if other > 1 {
+		parts = append(parts, fmt.Sprintf("%d other commits", other))
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// DirectoryFiles is the set of changed files in one directory
+type DirectoryFiles struct {
+	Dir   string
+	Files []DirectoryFile
+}
+
+// DirectoryFile is a changed file listed under its directory
+type DirectoryFile struct {
+	Name   string
+	Change string // e.g. "added", "+12 -3" or "binary"
+	git.FileChange
+}
+
+// FilesByDirectory groups the changed files by directory
+func (d *Data) FilesByDirectory() []DirectoryFiles {
+	groups := map[string][]DirectoryFile{}
+	for _, file := range d.Files {
+		dir := path.Dir(file.Path)
+		if dir == "." {
+			dir = "/"
+		} else {
+			dir += "/"
+		}
+
+		change := fmt.Sprintf("+%d -%d", file.Additions, file.Deletions)
+		switch {
+		case file.Binary:
+			change = "binary"
+		case file.Status == "added" || file.Status == "deleted":
+			change = file.Status
+		}
+
+		groups[dir] = append(groups[dir], DirectoryFile{
+			Name:       path.Base(file.Path),
+			Change:     change,
+			FileChange: file,
+		})
+	}
+
+	dirs := make([]string, 0, len(groups))
+	for dir := range groups {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	result := make([]DirectoryFiles, 0, len(dirs))
+	for _, dir := range dirs {
+		result = append(result, DirectoryFiles{Dir: dir, Files: groups[dir]})
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+
+	"one/internal/git"
+)
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []string
+		want     string
+	}{
+		{"no commits", nil, ""},
+		{"single fix", []string{"fix: handle nil ticket"}, "1 fix"},
+		{
+			name:     "types in summary order",
+			subjects: []string{"fix: a", "feat(api): b", "feat!: c", "docs: d"},
+			want:     "2 features, 1 fix and 1 docs change",
+		},
+		{
+			name:     "other commits",
+			subjects: []string{"Feat: a", "Update README", "wip: stuff"},
+			want:     "1 feature and 2 other commits",
+		},
+		{"one other commit", []string{"Update README"}, "1 other commit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &Data{}
+			for _, subject := range tt.subjects {
+				data.Commits = append(data.Commits, git.Commit{Subject: subject})
+			}
+			if got := data.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilesByDirectory(t *testing.T) {
+	files := []git.FileChange{
+		{Path: "main.go", Status: "modified", Additions: 3, Deletions: 1},
+		{Path: "cmd/pr.go", Status: "modified", Additions: 12, Deletions: 4},
+		{Path: "cmd/merge.go", Status: "added", Additions: 80},
+		{Path: "docs/logo.png", Status: "added", Binary: true},
+		{Path: "internal/git/old.go", Status: "deleted", Deletions: 20},
+	}
+
+	want := []DirectoryFiles{
+		{Dir: "/", Files: []DirectoryFile{
+			{Name: "main.go", Change: "+3 -1", FileChange: files[0]},
+		}},
+		{Dir: "cmd/", Files: []DirectoryFile{
+			{Name: "pr.go", Change: "+12 -4", FileChange: files[1]},
+			{Name: "merge.go", Change: "added", FileChange: files[2]},
+		}},
+		{Dir: "docs/", Files: []DirectoryFile{
+			{Name: "logo.png", Change: "binary", FileChange: files[3]},
+		}},
+		{Dir: "internal/git/", Files: []DirectoryFile{
+			{Name: "old.go", Change: "deleted", FileChange: files[4]},
+		}},
+	}
+
+	data := &Data{Files: files}
+	if got := data.FilesByDirectory(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilesByDirectory() = %+v, want %+v", got, want)
+	}
+}
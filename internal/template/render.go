@@ -1,23 +1,195 @@
 package template
 
 import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
+	gotemplate "text/template"
 	"time"
+	"unicode/utf8"
+
+	"one/internal/git"
 )
 
-// Context holds template variables
-type Context map[string]string
+// Data is what PR title and body templates are rendered with
+type Data struct {
+	Ticket     Ticket
+	Branch     string
+	BaseBranch string
+	Date       string
+	Closes     string // GitHub closing keyword, e.g. "Closes #123"
+	PRURL      string // set once the pull request exists
+	Author     Author
+	Commits    []git.Commit
+	Files      []git.FileChange
+	Stats      git.DiffStats
+}
+
+// Ticket holds the ticket fields available to templates
+type Ticket struct {
+	ID       string
+	Title    string
+	Type     string
+	Priority string
+	State    string
+	Assignee string
+	URL      string
+	Labels   []string
+}
+
+// Author is the git user opening the PR
+type Author struct {
+	Name  string
+	Email string
+}
+
+// legacyVariables maps the {placeholder} names of the original template
+// syntax to template fields
+var legacyVariables = map[string]string{
+	"ticket_id":       ".Ticket.ID",
+	"ticket_title":    ".Ticket.Title",
+	"ticket_url":      ".Ticket.URL",
+	"ticket_state":    ".Ticket.State",
+	"ticket_assignee": ".Ticket.Assignee",
+	"closes":          ".Closes",
+	"pr_url":          ".PRURL",
+	"branch_name":     ".Branch",
+	"base_branch":     ".BaseBranch",
+	"date":            ".Date",
+	"author":          ".Author.Name",
+	"email":           ".Author.Email",
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// actionPattern matches Go template actions such as {{end}}
+var actionPattern = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+
+// funcs are the helper functions available to templates
+var funcs = gotemplate.FuncMap{
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+	"truncate": truncate,
+	"slug":     git.SanitizeBranchName,
+	"join":     join,
+	"default":  defaultValue,
+}
+
+// Execute renders a template with Go text/template syntax. The original
+// {ticket_id} style placeholders keep working and are rewritten to their
+// template fields first.
+func Execute(text string, data *Data) (string, error) {
+	tmpl, err := parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// ExecuteLegacy fills in only the {ticket_id} style placeholders and leaves
+// everything else as written, for text that is not meant as a Go template
+func ExecuteLegacy(text string, data *Data) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		field, ok := legacyVariables[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		value, err := Execute("{{"+field+"}}", data)
+		if err != nil {
+			return placeholder
+		}
+		return value
+	})
+}
+
+// Check parses a template and returns the {placeholders} in it that are not
+// known variables
+func Check(text string) ([]string, error) {
+	if _, err := parse(text); err != nil {
+		return nil, err
+	}
 
-// Render replaces template variables with values from the context
-func Render(template string, context Context) string {
-	result := template
+	// Actions written without spaces, like {{end}}, are not placeholders
+	var unknown []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(actionPattern.ReplaceAllString(text, ""), -1) {
+		if _, ok := legacyVariables[match[1]]; !ok {
+			unknown = append(unknown, match[1])
+		}
+	}
+	return unknown, nil
+}
 
-	for key, value := range context {
-		placeholder := "{" + key + "}"
-		result = strings.ReplaceAll(result, placeholder, value)
+func parse(text string) (*gotemplate.Template, error) {
+	tmpl, err := gotemplate.New("pr").
+		Funcs(funcs).
+		Option("missingkey=zero").
+		Parse(convertLegacy(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
 	}
+	return tmpl, nil
+}
+
+// convertLegacy rewrites {ticket_id} style placeholders to {{.Ticket.ID}}.
+// Unknown names are left alone so literal braces survive.
+func convertLegacy(text string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if field, ok := legacyVariables[placeholder[1:len(placeholder)-1]]; ok {
+			return "{{" + field + "}}"
+		}
+		return placeholder
+	})
+}
 
-	return result
+// truncate shortens s to at most n characters, ending with an ellipsis
+func truncate(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
+
+// join joins the elements of a list with sep
+func join(sep string, list interface{}) string {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, fmt.Sprint(v.Index(i).Interface()))
+	}
+	return strings.Join(items, sep)
+}
+
+// defaultValue returns value, or fallback when value is empty
+func defaultValue(fallback, value interface{}) interface{} {
+	if value == nil {
+		return fallback
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		if v.Len() == 0 {
+			return fallback
+		}
+	default:
+		if v.IsZero() {
+			return fallback
+		}
+	}
+	return value
 }
 
 // BuildTicketURL generates a ticket URL based on the ticket system
@@ -0,0 +1,55 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExecuteLegacy(t *testing.T) {
+	data := &Data{Ticket: Ticket{ID: "PROJ-1"}, Branch: "PROJ-1-login"}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"placeholders", "Fixes {ticket_id} on {branch_name}", "Fixes PROJ-1 on PROJ-1-login"},
+		{"literal actions", "Use {{ .Values.image }} for {ticket_id}", "Use {{ .Values.image }} for PROJ-1"},
+		{"unknown placeholders", "{{#if ready}}{name}{{/if}}", "{{#if ready}}{name}{{/if}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExecuteLegacy(tt.text, data); got != tt.want {
+				t.Errorf("ExecuteLegacy(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		unknown []string
+		wantErr bool
+	}{
+		{"known placeholders", "[{ticket_id}] {branch_name}", nil, false},
+		{"actions without spaces", "{{if .Commits}}x{{else}}y{{end}}", nil, false},
+		{"unknown placeholder", "{ticket_id} {title}", []string{"title"}, false},
+		{"placeholder next to an action", "{{.Branch}}{name}", []string{"name"}, false},
+		{"invalid template", "{{ if .Commits }}", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unknown, err := Check(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(unknown, tt.unknown) {
+				t.Errorf("Check(%q) = %q, want %q", tt.text, unknown, tt.unknown)
+			}
+		})
+	}
+}